@@ -42,21 +42,6 @@ annotations := array.concat(
 	[annotation | annotation := input.rules[_].annotations[_]],
 )
 
-# METADATA
-# description: |
-#   map of all ignore directive comments, like ("# regal ignore:line-length")
-#   found in input AST, indexed by the row they're at
-ignore_directives[row] := rules if {
-	some comment in comments_decoded
-
-	contains(comment.text, "regal ignore:")
-
-	loc := util.to_location_object(comment.location)
-	row := loc.row + 1
-
-	rules := regex.split(`,\s*`, trim_space(regex.replace(comment.text, `^.*regal ignore:\s*(\S+)`, "$1")))
-}
-
 # METADATA
 # description: |
 #   returns an array of partitions, i.e. arrays containing all comments
@@ -32,9 +32,11 @@ merged_config := data.internal.combined_config
 default rules := {}
 
 # METADATA
-# description: the merged (default and user) configuration for rules
+# description: |
+#   the merged (default and user) configuration for rules, with the rule
+#   configuration of any overrides matching the file linted applied on top
 # scope: document
-rules := merged_config.rules
+rules := object.union(merged_config.rules, _override_rules_for_file)
 
 # METADATA
 # description: the resolved capabilities sourced from Regal and user configuration
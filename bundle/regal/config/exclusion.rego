@@ -7,11 +7,21 @@ package regal.config
 #
 #   imitates .gitignore pattern matching as best it can
 #   ref: https://git-scm.com/docs/gitignore#_pattern_format
+# scope: document
 excluded_file(category, title, file) if {
 	some compiled in _patterns_compiler(rules[category][title].ignore.files)
 	glob.match(compiled, ["/"], file)
 }
 
+# unlike the level set for all files, the level set by an override can't be
+# overridden by enabling the rule, so an override setting the level to ignore
+# excludes the files matching it
+excluded_file(category, title, file) if _override_rules(file)[category][title].level == "ignore"
+
+# METADATA
+# description: determines if the file being linted is excluded from the rule, relative to the project root
+excluded_input_file(category, title) if excluded_file(category, title, _file_name_relative_to_root)
+
 # METADATA
 # description: determines if file is ignored globally, via an override or the configuration
 ignored_globally(file) if {
@@ -0,0 +1,92 @@
+package regal.config
+
+# METADATA
+# description: |
+#   explains whether a rule is enabled for the file provided in input, and why,
+#   considering the same ignored files, command line flags, overrides, ignored
+#   files and notices as the main policy does when determining the rules to run
+# scope: document
+explain(category, title) := {
+	"enabled": _explained_enabled(category, title),
+	"level": level_for_rule(category, title),
+	"reasons": [reason |
+		some reasons in [
+			_ignore_files_reasons,
+			_flag_reasons(_params, category, title),
+			_override_reasons(category, title),
+			_rule_ignore_files_reasons(category, title),
+			_notice_reasons(category, title),
+		]
+		some reason in reasons
+	],
+}
+
+default _explained_enabled(_, _) := false
+
+_explained_enabled(category, title) if {
+	not _explained_file_excluded(category, title)
+	not ignored_rule(category, title)
+	not _has_notices(category, title)
+}
+
+_explained_file_excluded(_, _) if ignored_globally(_file_name_relative_to_root)
+
+_explained_file_excluded(category, title) if excluded_file(category, title, _file_name_relative_to_root)
+
+_has_notices(category, title) if count(data.regal.rules[category][title].notices) > 0
+
+_ignore_files_reasons := [sprintf("file matches pattern %q passed with --ignore-files", [pattern]) |
+	some pattern in _params.ignore_files
+	_matches_any([pattern], _file_name_relative_to_root)
+] if {
+	count(_params.ignore_files) > 0
+} else := [sprintf("file matches pattern %q of ignore.files in configuration", [pattern]) |
+	some pattern in merged_config.ignore.files
+	_matches_any([pattern], _file_name_relative_to_root)
+]
+
+_flag_reasons(params, category, title) := [reason |
+	some reasons in [
+		["rule disabled with --disable" | title in params.disable],
+		["all rules disabled with --disable-all" |
+			params.disable_all == true
+			not category in params.enable_category
+			not title in params.enable
+		],
+		[sprintf("category %s disabled with --disable-category", [category]) |
+			category in params.disable_category
+			not title in params.enable
+		],
+		["rule enabled with --enable" | title in params.enable],
+		["all rules enabled with --enable-all" |
+			params.enable_all == true
+			not category in params.disable_category
+			not title in params.disable
+		],
+		[sprintf("category %s enabled with --enable-category", [category]) |
+			category in params.enable_category
+			not title in params.disable
+		],
+	]
+	some reason in reasons
+]
+
+_override_reasons(category, title) := [reason |
+	some override in merged_config.overrides
+	_matches_any(override.files, _file_name_relative_to_root)
+
+	some attribute, value in override.rules[category][title]
+
+	reason := sprintf("%s set to %v by override for files matching %s", [attribute, value, concat(", ", override.files)])
+]
+
+_rule_ignore_files_reasons(category, title) := [reason |
+	some pattern in rules[category][title].ignore.files
+	_matches_any([pattern], _file_name_relative_to_root)
+
+	reason := sprintf("file matches pattern %q of ignore.files in rule configuration", [pattern])
+]
+
+_notice_reasons(category, title) := [sprintf("rule skipped: %s", [notice.description]) |
+	some notice in data.regal.rules[category][title].notices
+]
@@ -0,0 +1,53 @@
+package regal.config_test
+
+import data.regal.config
+
+test_explain_enabled_rule if {
+	e := config.explain("style", "line-length") with config.merged_config as _line_length_config("error")
+		with input.regal.file.name as "p.rego"
+
+	e == {"enabled": true, "level": "error", "reasons": []}
+}
+
+test_explain_rule_ignored_by_override_and_rule_ignore_files if {
+	e := config.explain("style", "line-length") with config.merged_config as {
+		"rules": {"style": {"line-length": {"level": "error", "ignore": {"files": ["*_test.rego"]}}}},
+		"overrides": [{"files": ["generated/**"], "rules": {"style": {"line-length": {"level": "ignore"}}}}],
+	}
+		with input.regal.file.name as "generated/p_test.rego"
+
+	e == {
+		"enabled": false,
+		"level": "ignore",
+		"reasons": [
+			"level set to ignore by override for files matching generated/**",
+			`file matches pattern "*_test.rego" of ignore.files in rule configuration`,
+		],
+	}
+}
+
+test_explain_rule_enabled_and_file_ignored_from_command_line if {
+	params := object.union(config._params, {"enable": ["line-length"], "ignore_files": ["generated/"]})
+
+	e := config.explain("style", "line-length") with config.merged_config as _line_length_config("ignore")
+		with input.regal.file.name as "generated/p.rego"
+		with data.eval.params as params
+
+	e == {
+		"enabled": false,
+		"level": "error",
+		"reasons": [
+			`file matches pattern "generated/" passed with --ignore-files`,
+			"rule enabled with --enable",
+		],
+	}
+}
+
+test_explain_category_disabled_from_command_line if {
+	e := config.explain("style", "line-length") with config.merged_config as _line_length_config("error")
+		with data.eval.params as object.union(config._params, {"disable_category": ["style"]})
+
+	e == {"enabled": false, "level": "ignore", "reasons": ["category style disabled with --disable-category"]}
+}
+
+_line_length_config(level) := {"rules": {"style": {"line-length": {"level": level}}}}
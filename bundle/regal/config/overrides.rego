@@ -0,0 +1,20 @@
+package regal.config
+
+default _override_rules_for_file := {}
+
+_override_rules_for_file := _override_rules(_file_name_relative_to_root)
+
+_file_name_relative_to_root := trim_prefix(input.regal.file.name, "/") if path_prefix == "/"
+
+_file_name_relative_to_root := trim_prefix(input.regal.file.name, concat("", [path_prefix, "/"])) if path_prefix != "/"
+
+# the rule configuration of all overrides matching file, where later overrides take precedence
+_override_rules(file) := object.union_n([override.rules |
+	some override in merged_config.overrides
+	_matches_any(override.files, file)
+])
+
+_matches_any(patterns, file) if {
+	some compiled in _patterns_compiler(patterns)
+	glob.match(compiled, ["/"], file)
+}
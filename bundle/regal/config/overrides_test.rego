@@ -0,0 +1,67 @@
+package regal.config_test
+
+import data.regal.config
+
+_config := {
+	"rules": {"style": {
+		"line-length": {"level": "error", "max-line-length": 120},
+		"todo-comment": {"level": "error"},
+	}},
+	"overrides": [
+		{
+			"files": ["generated/**"],
+			"rules": {"style": {"line-length": {"max-line-length": 200}}},
+		},
+		{
+			"files": ["generated/legacy/"],
+			"rules": {"style": {"line-length": {"level": "warning"}}},
+		},
+		{
+			"files": ["scratch/**"],
+			"rules": {"style": {"todo-comment": {"level": "ignore"}}},
+		},
+	],
+}
+
+test_overrides_applied_to_matching_file if {
+	r := config.rules with config.merged_config as _config
+		with input.regal.file.name as "generated/legacy/p.rego"
+
+	r.style == {
+		"line-length": {"level": "warning", "max-line-length": 200},
+		"todo-comment": {"level": "error"},
+	}
+}
+
+test_overrides_applied_relative_to_path_prefix if {
+	r := config.rules with config.merged_config as _config
+		with config.path_prefix as "/workspace"
+		with input.regal.file.name as "/workspace/generated/p.rego"
+
+	r.style["line-length"] == {"level": "error", "max-line-length": 200}
+}
+
+test_overrides_not_applied_to_other_files if {
+	r := config.rules with config.merged_config as _config
+		with input.regal.file.name as "policy/generated/p.rego"
+
+	r == _config.rules
+}
+
+test_overrides_not_applied_without_file if {
+	r := config.rules with config.merged_config as _config
+
+	r == _config.rules
+}
+
+test_level_for_rule_from_override if {
+	config.level_for_rule("style", "line-length") == "warning" with config.merged_config as _config
+		with input.regal.file.name as "generated/legacy/p.rego"
+}
+
+test_override_ignoring_rule_excludes_file_even_if_enabled if {
+	config.excluded_file("style", "todo-comment", "scratch/p.rego") with config.merged_config as _config
+		with data.eval.params.enable as ["todo-comment"]
+
+	not config.excluded_file("style", "todo-comment", "policy/p.rego") with config.merged_config as _config
+}
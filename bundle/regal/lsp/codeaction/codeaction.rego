@@ -101,6 +101,7 @@ rules := {
 	"use-assignment-operator": ["Replace = with := in assignment", ["target", "diagnostic"]],
 	"no-whitespace-comment": ["Format comment to have leading whitespace", ["target", "diagnostic"]],
 	"non-raw-regex-pattern": ["Replace \" with ` in regex pattern", ["target", "diagnostic"]],
+	"unused-ignore-directive": ["Remove unused ignore directive", ["target", "diagnostic"]],
 	"directory-package-mismatch": [
 		"Move file so that directory structure mirrors package path",
 		["target", "diagnostic"],
@@ -28,7 +28,10 @@ items contains item if {
 	contains(comment.text, "regal ignore:")
 
 	loc := util.to_location_no_text(comment.location)
-	rules := regex.split(`,\s*`, trim_space(regex.replace(comment.text, `^.*regal ignore:\s*(\S+)`, "$1")))
+
+	# options following the rules, like until=2026-12-31, are not included
+	directive := regex.replace(comment.text, `^.*regal ignore:\s*([^\s,]+(?:,\s*[^\s,]+)*).*$`, "$1")
+	rules := regex.split(`,\s*`, trim_space(directive))
 
 	some rule in rules
 
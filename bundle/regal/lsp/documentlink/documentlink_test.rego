@@ -46,3 +46,29 @@ test_documentlink_ranges_in_inline_ignores if {
 		},
 	}
 }
+
+test_documentlink_ranges_in_inline_ignores_with_options if {
+	items := documentlink.items with input as {"params": {"textDocument": {"uri": "file://p.rego"}}}
+		with data.workspace.parsed["file://p.rego"] as regal.parse_module("p.rego", concat("\n", [
+			"package p",
+			"",
+			`# regal ignore:messy-rule until=2026-12-31 reason="legacy"`,
+			"ignored if directives",
+		]))
+		with data.workspace.config.rules as {"style": {"messy-rule": {}}}
+
+	items = {{
+		"range": {
+			"end": {
+				"character": 25,
+				"line": 2,
+			},
+			"start": {
+				"character": 15,
+				"line": 2,
+			},
+		},
+		"target": "https://docs.styra.com/regal/rules/style/messy-rule",
+		"tooltip": "See documentation for messy-rule",
+	}}
+}
@@ -8,18 +8,12 @@
 #   scope of a single file
 package regal.main
 
-import data.regal.ast
 import data.regal.config
-import data.regal.util
 
 # METADATA
 # description: set of all notices returned from linter rules
 lint.notices contains _grouped_notices[_][_][_] if "lint" in input.regal.operations
 
-# METADATA
-# description: map of all ignore directives encountered when linting
-lint.ignore_directives[input.regal.file.name] := ast.ignore_directives if "lint" in input.regal.operations
-
 # METADATA
 # description: all violations from non-aggregate rules
 lint.violations := report if "lint" in input.regal.operations
@@ -86,8 +80,6 @@ report contains violation if {
 	count(object.get(_grouped_notices, [category, title], [])) == 0
 
 	some violation in data.regal.rules[category][title].report
-
-	not _ignored(violation, ast.ignore_directives)
 }
 
 # Check custom rules
@@ -101,7 +93,6 @@ report contains violation if {
 
 	not config.ignored_rule(category, title)
 	not config.excluded_file(category, title, file_name_relative_to_root)
-	not _ignored(violation, ast.ignore_directives)
 }
 
 # METADATA
@@ -160,13 +151,6 @@ aggregate_report contains violation if {
 
 	# regal ignore:with-outside-test-context
 	some violation in data.regal.rules[category][title].aggregate_report with input as input_for_rule
-
-	not _ignored(violation, util.keys_to_numbers(object.get(
-		input.ignore_directives,
-		# some aggregate violations won't have a location at all, like no-defined-entrypoint
-		object.get(violation, ["location", "file"], ""),
-		{},
-	)))
 }
 
 # METADATA
@@ -189,21 +173,6 @@ aggregate_report contains violation if {
 
 	# regal ignore:with-outside-test-context
 	some violation in data.custom.regal.rules[category][title].aggregate_report with input as input_for_rule
-
-	# don't assume that the author included a location in the violation, although they really should
-	ignore_directives := object.get(input, ["ignore_directives", object.get(violation, ["location", "file"], "")], {})
-
-	not _ignored(violation, util.keys_to_numbers(ignore_directives))
-}
-
-_ignored(violation, directives) if {
-	ignored_rules := directives[util.to_location_object(violation.location).row]
-	violation.title in ignored_rules
-}
-
-_ignored(violation, directives) if {
-	ignored_rules := directives[util.to_location_object(violation.location).row + 1]
-	violation.title in ignored_rules
 }
 
 _null_to_empty(x) := [] if {
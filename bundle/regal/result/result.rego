@@ -197,6 +197,27 @@ location(x) := _with_text(util.to_location_object(x.location))
 location(x) := _with_text(util.to_location_object(x[0].location)) if is_array(x)
 location(x) := _with_text(util.to_location_object(x)) if is_string(x)
 
+# METADATA
+# description: |
+#   creates a related location, i.e. another place in the code relevant to understanding a violation,
+#   like the rule a duplicate rule duplicates. x is any value accepted by `location`, and message
+#   describes how the location relates to the violation. See `with_related_locations`
+related_location(x, message) := {
+	"message": message,
+	"location": location(x).location,
+}
+
+# METADATA
+# description: |
+#   adds related locations (see `related_location`) to a violation, as created by `fail`, e.g:
+#   result.with_related_locations(result.fail(...), [result.related_location(rule, "first defined here")])
+with_related_locations(violation, related_locations) := object.union(
+	violation,
+	{"related_locations": related_locations},
+) if {
+	count(related_locations) > 0
+} else := violation
+
 # METADATA
 # description: creates a location where x is the start, and y is the end (calculated from `text`)
 ranged_location_between(x, y) := object.union(
@@ -15,10 +15,13 @@ report contains violation if {
 		location := util.to_location_object(input.rules[index].location)
 	]
 
-	violation := result.fail(rego.metadata.chain(), object.union(
-		result.location(input.rules[first]),
-		{"description": _message(dup_locations)},
-	))
+	violation := result.with_related_locations(
+		result.fail(rego.metadata.chain(), object.union(
+			result.location(input.rules[first]),
+			{"description": _message(dup_locations)},
+		)),
+		[result.related_location(input.rules[index], "Duplicate rule") | some index in util.rest(indices)],
+	)
 }
 
 _message(locations) := sprintf("Duplicate rule found at line %d", [locations[0].row]) if count(locations) == 1
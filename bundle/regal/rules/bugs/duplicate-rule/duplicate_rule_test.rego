@@ -28,6 +28,7 @@ test_fail_simple_duplicate_rule if {
 			"text": "\tallow if {",
 			"end": {"col": 3, "row": 8},
 		},
+		"related_locations": [_related_location(10, 12)],
 		"related_resources": [{
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/duplicate-rule", "bugs"),
@@ -80,6 +81,7 @@ test_fail_multiple_duplicate_rules if {
 				"row": 12,
 			},
 		},
+		"related_locations": [_related_location(14, 16), _related_location(18, 20)],
 		"related_resources": [{
 			"description": "documentation",
 			"ref": config.docs.resolve_url("$baseUrl/$category/duplicate-rule", "bugs"),
@@ -87,3 +89,14 @@ test_fail_multiple_duplicate_rules if {
 		"title": "duplicate-rule",
 	}}
 }
+
+_related_location(row, end_row) := {
+	"message": "Duplicate rule",
+	"location": {
+		"col": 2,
+		"file": "policy.rego",
+		"row": row,
+		"text": "\tallow if {",
+		"end": {"col": 3, "row": end_row},
+	},
+}
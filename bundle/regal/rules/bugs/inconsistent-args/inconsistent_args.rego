@@ -32,11 +32,25 @@ report contains violation if {
 
 	_inconsistent_args(position)
 
-	args := _find_function_by_name(name).head.args
+	violation := _violation(_functions_by_name(name))
+}
 
-	violation := result.fail(rego.metadata.chain(), result.ranged_location_between(args[0], regal.last(args)))
+_violation(functions) := result.with_related_locations(
+	result.fail(rego.metadata.chain(), result.ranged_location_between(args[0], regal.last(args))),
+	_related_locations(functions),
+) if {
+	args := functions[1].head.args
 }
 
+_related_locations(functions) := [related |
+	some i, fn in functions
+	i != 1
+	related := object.union(
+		result.related_location(fn.head.args[0], "Other definition of function"),
+		{"location": {"end": result.location(regal.last(fn.head.args)).location.end}},
+	)
+]
+
 _arity_mismatch(args_list) if {
 	len := count(args_list[0])
 	some arr in args_list
@@ -52,9 +66,9 @@ _inconsistent_args(position) if {
 	count(named_vars) > 1
 }
 
-# Return the _second_ function found by name, as that
-# is reasonably the location the inconsistency is found
-_find_function_by_name(name) := [fn |
+# The violation is reported at the _second_ function found by name,
+# as that is reasonably the location the inconsistency is found
+_functions_by_name(name) := [fn |
 	some fn in ast.functions
 	ast.ref_to_string(fn.head.ref) == name
-][1]
+]
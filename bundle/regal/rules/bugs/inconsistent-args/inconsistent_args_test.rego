@@ -14,15 +14,26 @@ test_fail_inconsistent_args if {
 	`)
 	r := rule.report with input as module
 
-	r == expected_with_location({
-		"row": 7,
-		"col": 6,
-		"end": {
+	r == expected_with_locations(
+		{
 			"row": 7,
-			"col": 10,
+			"col": 6,
+			"end": {
+				"row": 7,
+				"col": 10,
+			},
+			"text": "\tfoo(b, a) if b > a",
+		},
+		{
+			"row": 6,
+			"col": 6,
+			"end": {
+				"row": 6,
+				"col": 10,
+			},
+			"text": "\tfoo(a, b) if a == b",
 		},
-		"text": "\tfoo(b, a) if b > a",
-	})
+	)
 }
 
 test_fail_nested_inconsistent_args if {
@@ -31,15 +42,26 @@ test_fail_nested_inconsistent_args if {
 	a.b.foo(b, a) if b > a
 	`)
 	r := rule.report with input as module
-	r == expected_with_location({
-		"col": 10,
-		"row": 7,
-		"text": "\ta.b.foo(b, a) if b > a",
-		"end": {
-			"col": 14,
+	r == expected_with_locations(
+		{
+			"col": 10,
 			"row": 7,
+			"text": "\ta.b.foo(b, a) if b > a",
+			"end": {
+				"col": 14,
+				"row": 7,
+			},
 		},
-	})
+		{
+			"col": 10,
+			"row": 6,
+			"text": "\ta.b.foo(a, b) if a == b",
+			"end": {
+				"col": 14,
+				"row": 6,
+			},
+		},
+	)
 }
 
 test_success_not_inconsistent_args if {
@@ -106,4 +128,10 @@ expected := {
 	"location": {"file": "policy.rego"},
 }
 
-expected_with_location(location) := {object.union(expected, {"location": location})} if is_object(location)
+expected_with_locations(location, related_location) := {object.union(expected, {
+	"location": location,
+	"related_locations": [{
+		"message": "Other definition of function",
+		"location": object.union(related_location, {"file": "policy.rego"}),
+	}],
+})}
@@ -1,5 +1,10 @@
 # METADATA
 # description: Outside reference to internal rule or function
+# custom:
+#   config:
+#     include-test-files:
+#       description: whether to report references to internal rules in test files
+#       type: boolean
 package regal.rules.bugs["leaked-internal-reference"]
 
 import data.regal.ast
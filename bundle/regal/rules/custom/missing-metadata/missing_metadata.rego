@@ -1,5 +1,13 @@
 # METADATA
 # description: Package or rule missing metadata
+# custom:
+#   config:
+#     except-package-path-pattern:
+#       description: pattern of package paths to exclude from the requirement
+#       type: string
+#     except-rule-path-pattern:
+#       description: pattern of rule paths to exclude from the requirement
+#       type: string
 package regal.rules.custom["missing-metadata"]
 
 import data.regal.ast
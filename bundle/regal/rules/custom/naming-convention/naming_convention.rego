@@ -1,5 +1,20 @@
 # METADATA
 # description: Naming convention violation
+# custom:
+#   config:
+#     conventions:
+#       description: naming conventions, each with a pattern and the targets it applies to
+#       type: array
+#       items:
+#         type: object
+#         properties:
+#           pattern:
+#             type: string
+#           targets:
+#             type: array
+#             items:
+#               type: string
+#               enum: [package, rule, function, variable]
 package regal.rules.custom["naming-convention"]
 
 import data.regal.ast
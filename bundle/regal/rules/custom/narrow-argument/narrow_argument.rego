@@ -1,5 +1,12 @@
 # METADATA
 # description: Function argument can be narrowed
+# custom:
+#   config:
+#     exclude-args:
+#       description: names of arguments to exclude
+#       type: array
+#       items:
+#         type: string
 package regal.rules.custom["narrow-argument"]
 
 import data.regal.ast
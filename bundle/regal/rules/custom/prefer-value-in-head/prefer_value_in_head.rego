@@ -1,5 +1,15 @@
 # METADATA
 # description: Prefer value in rule head
+# custom:
+#   config:
+#     except-var-names:
+#       description: names of variables to exclude
+#       type: array
+#       items:
+#         type: string
+#     only-scalars:
+#       description: whether to only suggest moving scalar values to the head
+#       type: boolean
 package regal.rules.custom["prefer-value-in-head"]
 
 import data.regal.ast
@@ -27,5 +27,11 @@ report contains violation if {
 
 	identifier in array.slice(_identifiers, 0, i)
 
-	violation := result.fail(rego.metadata.chain(), result.location(input.imports[i].path))
+	violation := result.with_related_locations(
+		result.fail(rego.metadata.chain(), result.location(input.imports[i].path)),
+		[result.related_location(input.imports[j].path, "Shadowed import") |
+			some j, other in array.slice(_identifiers, 0, i)
+			other == identifier
+		],
+	)
 }
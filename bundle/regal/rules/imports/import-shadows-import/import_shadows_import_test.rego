@@ -29,6 +29,19 @@ import data.foo
 			"text": `import data.foo`,
 		},
 		"level": "error",
+		"related_locations": [{
+			"message": "Shadowed import",
+			"location": {
+				"col": 8,
+				"file": "policy.rego",
+				"row": 4,
+				"end": {
+					"col": 16,
+					"row": 4,
+				},
+				"text": `import data.foo`,
+			},
+		}],
 	}}
 }
 
@@ -57,5 +70,18 @@ import data.bar as foo
 			"text": `import data.bar as foo`,
 		},
 		"level": "error",
+		"related_locations": [{
+			"message": "Shadowed import",
+			"location": {
+				"col": 8,
+				"file": "policy.rego",
+				"row": 4,
+				"end": {
+					"col": 16,
+					"row": 4,
+				},
+				"text": `import data.foo`,
+			},
+		}],
 	}}
 }
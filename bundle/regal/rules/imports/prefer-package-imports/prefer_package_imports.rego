@@ -1,5 +1,12 @@
 # METADATA
 # description: Prefer importing packages over rules
+# custom:
+#   config:
+#     ignore-import-paths:
+#       description: import paths to exclude
+#       type: array
+#       items:
+#         type: string
 package regal.rules.imports["prefer-package-imports"]
 
 import data.regal.ast
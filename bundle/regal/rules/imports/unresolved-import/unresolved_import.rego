@@ -1,5 +1,12 @@
 # METADATA
 # description: Unresolved import
+# custom:
+#   config:
+#     except-imports:
+#       description: import paths to exclude, which may end with a wildcard
+#       type: array
+#       items:
+#         type: string
 package regal.rules.imports["unresolved-import"]
 
 import data.regal.ast
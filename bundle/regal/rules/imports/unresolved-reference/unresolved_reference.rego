@@ -1,5 +1,17 @@
 # METADATA
 # description: Unresolved Reference
+# custom:
+#   config:
+#     except-paths:
+#       description: glob patterns of references to exclude
+#       type: array
+#       items:
+#         type: string
+#     excepted_export_patterns:
+#       description: glob patterns of rule names to exclude from exports
+#       type: array
+#       items:
+#         type: string
 package regal.rules.imports["unresolved-reference"]
 
 import data.regal.ast
@@ -1,5 +1,10 @@
 # METADATA
 # description: Line too long
+# custom:
+#   config:
+#     non-breakable-word-threshold:
+#       description: length of single words above which lines are not reported
+#       type: integer
 package regal.rules.style["line-length"]
 
 import data.regal.config
@@ -1,5 +1,10 @@
 # METADATA
 # description: Comment should start with whitespace
+# custom:
+#   config:
+#     except-pattern:
+#       description: pattern of comments to exclude
+#       type: string
 package regal.rules.style["no-whitespace-comment"]
 
 import data.regal.ast
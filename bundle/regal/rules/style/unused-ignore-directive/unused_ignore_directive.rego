@@ -0,0 +1,96 @@
+# METADATA
+# description: Unused ignore directive
+package regal.rules.style["unused-ignore-directive"]
+
+import data.regal.ast
+import data.regal.config
+import data.regal.result
+import data.regal.util
+
+# METADATA
+# description: |
+#   reports rules named in ignore directives that don't exist, as well as those that were
+#   evaluated for the file. Whether the latter suppressed any violations is only known once
+#   all rules have been evaluated, so the linter removes these reports for directives that did
+#   suppress violations
+report contains violation if {
+	some comment in ast.comments_decoded
+	some [title, offset] in _named_rules(comment.text)
+
+	description := _description(title)
+
+	loc := util.to_location_object(comment.location)
+
+	# the text of a comment starts after the # character
+	col := (loc.col + offset) + 1
+
+	violation := result.fail(rego.metadata.chain(), object.union(
+		result.location(sprintf("%d:%d:%d:%d", [loc.row, col, loc.row, col + count(title)])),
+		{"description": description},
+	))
+}
+
+# the rules named in an ignore directive, along with their offset in the comment text
+_named_rules(text) := {[title, offset] |
+	start := indexof(text, "regal ignore:") + count("regal ignore:")
+	directive := substring(text, start, -1)
+
+	# options following the rules, like until=2026-12-31, are not included
+	parts := split(regex.find_n(`^\s*[^\s,]+(?:,\s*[^\s,]+)*`, directive, 1)[0], ",")
+
+	some i, part in parts
+	title := trim_space(part)
+
+	# the parts preceding this one, including the commas that separated them
+	preceding := concat(",", array.concat(array.slice(parts, 0, i), [""]))
+	offset := (start + count(preceding)) + indexof(part, title)
+} if {
+	contains(text, "regal ignore:")
+}
+
+_description(title) := sprintf("Ignore directive names unknown rule %s", [title]) if {
+	not _known(title)
+} else := sprintf("Ignore directive for %s suppressed no violations", [title]) if {
+	_evaluated(title)
+}
+
+_known(title) if {
+	some rules in config.rules
+	rules[title]
+}
+
+_known(title) if {
+	some category
+	data.custom.regal.rules[category][title].report
+}
+
+_known(title) if {
+	some category
+	data.custom.regal.rules[category][title].aggregate
+}
+
+# aggregate rules are only evaluated when linting several files, and then only after all
+# other rules, so whether directives naming them suppressed anything is not determined.
+# rules excluded for the file by their ignore.files patterns, or by overrides, are never
+# evaluated, so directives naming them can't be known to be unused
+_evaluated(title) if {
+	some category, rules in config.rules
+	rules[title]
+
+	not config.ignored_rule(category, title)
+	not config.excluded_input_file(category, title)
+	not data.regal.rules[category][title].aggregate
+	not _skipped(category, title)
+}
+
+_evaluated(title) if {
+	some category
+	data.custom.regal.rules[category][title].report
+
+	not config.ignored_rule(category, title)
+	not config.excluded_input_file(category, title)
+	not data.custom.regal.rules[category][title].aggregate
+}
+
+# rules reporting notices, like those for missing capabilities, are skipped
+_skipped(category, title) if count(data.regal.rules[category][title].notices) > 0
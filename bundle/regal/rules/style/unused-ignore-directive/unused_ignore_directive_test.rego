@@ -0,0 +1,93 @@
+package regal.rules.style["unused-ignore-directive_test"]
+
+import data.regal.ast
+import data.regal.config
+import data.regal.rules.style["unused-ignore-directive"] as rule
+
+test_fail_unknown_rule if {
+	r := rule.report with input as ast.policy(`# regal ignore:prefer-snake-cas
+camelCase := true`)
+		with config.rules as {"style": {"prefer-snake-case": {"level": "error"}}}
+
+	r == {{
+		"category": "style",
+		"description": "Ignore directive names unknown rule prefer-snake-cas",
+		"related_resources": [{
+			"description": "documentation",
+			"ref": config.docs.resolve_url("$baseUrl/$category/unused-ignore-directive", "style"),
+		}],
+		"title": "unused-ignore-directive",
+		"location": {
+			"col": 16,
+			"file": "policy.rego",
+			"row": 3,
+			"end": {
+				"col": 32,
+				"row": 3,
+			},
+			"text": `# regal ignore:prefer-snake-cas`,
+		},
+		"level": "error",
+	}}
+}
+
+test_fail_evaluated_rules_reported_for_linter_to_determine_if_used if {
+	policy := ast.policy(`# regal ignore:prefer-snake-case, todo-comment until=2026-12-31 reason="a, b"
+camelCase := true`)
+
+	r := rule.report with input as policy
+		with config.rules as {"style": {
+			"prefer-snake-case": {"level": "error"},
+			"todo-comment": {"level": "error"},
+		}}
+
+	{[v.description, v.location.col, v.location.end.col] | some v in r} == {
+		["Ignore directive for prefer-snake-case suppressed no violations", 16, 33],
+		["Ignore directive for todo-comment suppressed no violations", 35, 47],
+	}
+}
+
+test_fail_directive_following_other_comment if {
+	r := rule.report with input as ast.policy(`camelCase := true # camelCase # regal ignore:foo,bar`)
+		with config.rules as {"style": {"prefer-snake-case": {"level": "error"}}}
+
+	{[v.description, v.location.col, v.location.end.col] | some v in r} == {
+		["Ignore directive names unknown rule foo", 46, 49],
+		["Ignore directive names unknown rule bar", 50, 53],
+	}
+}
+
+test_success_ignored_rule_not_reported if {
+	r := rule.report with input as ast.policy(`# regal ignore:prefer-snake-case
+camelCase := true`)
+		with config.rules as {"style": {"prefer-snake-case": {"level": "ignore"}}}
+
+	r == set()
+}
+
+test_success_rule_excluded_for_file_not_reported if {
+	r := rule.report with input as ast.policy(`# regal ignore:prefer-snake-case
+camelCase := true`)
+		with input.regal.file.name as "p/p.rego"
+		with config.rules as {"style": {"prefer-snake-case": {
+			"level": "error",
+			"ignore": {"files": ["p/*"]},
+		}}}
+
+	r == set()
+}
+
+test_success_aggregate_rule_not_reported if {
+	r := rule.report with input as ast.policy(`# regal ignore:unresolved-import
+import data.foo`)
+		with config.rules as {"imports": {"unresolved-import": {"level": "error"}}}
+
+	r == set()
+}
+
+test_success_no_ignore_directives if {
+	r := rule.report with input as ast.policy(`# regal is nice
+allow := true`)
+
+	r == set()
+}
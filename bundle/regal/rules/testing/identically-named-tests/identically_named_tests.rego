@@ -12,10 +12,17 @@ report contains violation if {
 
 	name in array.slice(test_names, 0, i)
 
-	violation := result.fail(rego.metadata.chain(), result.location(_rule_by_name(name, ast.tests).head))
+	tests := _rules_by_name(name, ast.tests)
+
+	violation := result.with_related_locations(
+		result.fail(rego.metadata.chain(), result.location(regal.last(tests).head)),
+		[result.related_location(test.head, "Test with the same name") |
+			some test in array.slice(tests, 0, count(tests) - 1)
+		],
+	)
 }
 
-_rule_by_name(name, rules) := regal.last([rule |
+_rules_by_name(name, rules) := [rule |
 	some rule in rules
 	rule.head.ref[0].value == name
-])
+]
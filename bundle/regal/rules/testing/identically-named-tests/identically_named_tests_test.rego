@@ -30,6 +30,19 @@ test_fail_identically_named_tests if {
 			"file": "foo_test.rego",
 			"text": "\ttest_foo if { true }",
 		},
+		"related_locations": [{
+			"message": "Test with the same name",
+			"location": {
+				"row": 4,
+				"col": 2,
+				"end": {
+					"col": 10,
+					"row": 4,
+				},
+				"file": "foo_test.rego",
+				"text": "\ttest_foo if { false }",
+			},
+		}],
 		"level": "error",
 	}}
 }
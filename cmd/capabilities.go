@@ -2,20 +2,35 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/open-policy-agent/regal/internal/capabilities"
 	"github.com/open-policy-agent/regal/internal/compile"
+	"github.com/open-policy-agent/regal/pkg/config"
+	"github.com/open-policy-agent/regal/pkg/rules"
 )
 
+type requiredParams struct {
+	engine string
+	format string
+}
+
 func init() {
 	capabilitiesCommand := &cobra.Command{
-		Hidden: true,
-		Use:    "capabilities",
-		Short:  "Print the capabilities of Regal",
-		Long:   "Show capabilities for Regal",
+		Use:   "capabilities",
+		Short: "Print the capabilities of Regal, or those required by policy",
+		Long: `Print the capabilities of Regal, i.e. the built-in functions, future keywords and features
+supported, as JSON.
+
+Use the required subcommand to instead report the capabilities used by policy, and the minimum version
+of OPA supporting them.`,
 		RunE: func(*cobra.Command, []string) error {
 			bs, err := json.MarshalIndent(compile.Capabilities(), "", "  ")
 			if err != nil {
@@ -28,5 +43,113 @@ func init() {
 		},
 	}
 
+	params := &requiredParams{}
+	requiredCommand := &cobra.Command{
+		Use:   "required <path> [path [...]]",
+		Short: "Report the minimum version of OPA able to run the provided policy",
+		Long: `Report the built-in functions, future keywords and features used by the provided policy, and the
+minimum version of OPA (or of the engine provided) supporting all of them, along with the constructs that
+prevent an older version from being used.
+
+Exits with a non-zero exit code if no version known to Regal supports all constructs used.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			if err := required(os.Stdout, args, params); err != nil {
+				if errors.As(err, &ExitError{}) {
+					return err
+				}
+
+				log.SetOutput(os.Stderr)
+				log.Println(err)
+
+				return exit(1)
+			}
+
+			return nil
+		},
+	}
+
+	flags := requiredCommand.Flags()
+	flags.StringVar(&params.engine, "engine", "opa", "engine to report the minimum version of: opa or eopa")
+	flags.StringVarP(&params.format, "format", "f", formatPretty, "set output format (pretty, json)")
+
+	capabilitiesCommand.AddCommand(requiredCommand)
 	RootCommand.AddCommand(capabilitiesCommand)
 }
+
+func required(w io.Writer, args []string, params *requiredParams) error {
+	if params.format != formatPretty && params.format != formatJSON {
+		return fmt.Errorf("unknown format '%s', expected one of: pretty, json", params.format)
+	}
+
+	paths, err := config.FilterIgnoredPaths(args, nil, true, "")
+	if err != nil {
+		return fmt.Errorf("failed to read policy files: %w", err)
+	}
+
+	input, err := rules.InputFromPaths(paths, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to read policy files: %w", err)
+	}
+
+	caps, err := capabilities.Required(input.FileContent)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	requirement, err := capabilities.MinimumVersion(params.engine, capabilities.Constructs(caps))
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if params.format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(requirement); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		fmt.Fprint(w, prettyRequirement(requirement))
+	}
+
+	if requirement.MinimumVersion == "" {
+		return exit(1)
+	}
+
+	return nil
+}
+
+func prettyRequirement(requirement *capabilities.Requirement) string {
+	var sb strings.Builder
+
+	if requirement.MinimumVersion == "" {
+		fmt.Fprintf(&sb, "No known version of %s supports all constructs used. Unsupported by the latest version:\n",
+			requirement.Engine)
+
+		for _, c := range requirement.Unsupported {
+			fmt.Fprintf(&sb, "- %s\n", c)
+		}
+
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "Minimum %s version required: %s\n", requirement.Engine, requirement.MinimumVersion)
+
+	if len(requirement.Drivers) == 0 {
+		fmt.Fprintln(&sb, "\nAll constructs used are supported by the oldest version known to Regal.")
+
+		return sb.String()
+	}
+
+	fmt.Fprintln(&sb, "\nConstructs requiring this version:")
+
+	for _, c := range requirement.Drivers {
+		fmt.Fprintf(&sb, "- %s\n", c)
+	}
+
+	return sb.String()
+}
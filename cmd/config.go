@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	rio "github.com/open-policy-agent/regal/internal/io"
+	"github.com/open-policy-agent/regal/pkg/config"
+	"github.com/open-policy-agent/regal/pkg/linter"
+)
+
+type configParams struct {
+	lintAndFixParams
+}
+
+func init() {
+	configCommand := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and validate Regal configuration",
+		Long: `Inspect and validate the configuration used by Regal.
+
+The configuration file is found the same way as when linting, by searching upwards from the provided path,
+or the current working directory, unless provided with --config-file.`,
+	}
+
+	showParams := &configParams{}
+	showCommand := &cobra.Command{
+		Use:   "show [path]",
+		Short: "Print the effective configuration",
+		Long:  "Print the effective configuration, i.e. the provided configuration with the user configuration merged on top.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  configRunE(configShow, showParams),
+	}
+
+	validateParams := &configParams{}
+	validateCommand := &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Validate the user configuration",
+		Long: `Validate the user configuration, reporting unknown categories, rules and rule attributes, and invalid levels.
+
+Exits with a non-zero exit code if any problems are found.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: configRunE(configValidate, validateParams),
+	}
+
+	explainParams := &configParams{}
+	explainCommand := &cobra.Command{
+		Use:   "explain <rule> [file]",
+		Short: "Explain whether a rule is enabled, and why",
+		Long: `Explain whether a rule is enabled, and why, considering the configuration, command line flags,
+ignored files and overrides, as well as notices like those for rules unsupported by the configured capabilities.
+
+If a file is provided, settings applying only to some files are considered for that file.
+
+Example:
+
+regal config explain line-length policy/authz.rego --disable-category style`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: configRunE(configExplain, explainParams),
+	}
+
+	schemaParams := &configParams{}
+	schemaCommand := &cobra.Command{
+		Use:   "schema [path]",
+		Short: "Print a JSON Schema for the configuration",
+		Long: `Print a JSON Schema for the configuration file, covering the rules built into Regal and any custom rules,
+for use by editors and other tools validating configuration.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: configRunE(configSchema, schemaParams),
+	}
+
+	for _, c := range []struct {
+		command *cobra.Command
+		params  *configParams
+	}{
+		{showCommand, showParams},
+		{validateCommand, validateParams},
+		{explainCommand, explainParams},
+		{schemaCommand, schemaParams},
+	} {
+		flags := c.command.Flags()
+		flags.StringVarP(&c.params.configFile, "config-file", "c", "", "set path of configuration file")
+		flags.StringVar(&c.params.preset, "preset", "", "set configuration preset to use")
+		flags.VarP(&c.params.rules, "rules", "r", "set custom rules file(s). This flag can be repeated.")
+		flags.BoolVar(&c.params.debug, "debug", false, "enable debug logging")
+	}
+
+	flags := explainCommand.Flags()
+	flags.VarP(&explainParams.disable, "disable", "d", "disable specific rule(s). This flag can be repeated.")
+	flags.BoolVarP(&explainParams.disableAll, "disable-all", "D", false, "disable all rules")
+	flags.VarP(&explainParams.disableCategory, "disable-category", "",
+		"disable all rules in a category. This flag can be repeated.")
+	flags.VarP(&explainParams.enable, "enable", "e", "enable specific rule(s). This flag can be repeated.")
+	flags.BoolVarP(&explainParams.enableAll, "enable-all", "E", false, "enable all rules")
+	flags.VarP(&explainParams.enableCategory, "enable-category", "",
+		"enable all rules in a category. This flag can be repeated.")
+	flags.VarP(&explainParams.ignoreFiles, "ignore-files", "",
+		"ignore all files matching a glob-pattern. This flag can be repeated.")
+
+	configCommand.AddCommand(showCommand, validateCommand, explainCommand, schemaCommand)
+	RootCommand.AddCommand(configCommand)
+}
+
+func configRunE(f func([]string, *configParams) error, params *configParams) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		if err := f(args, params); err != nil {
+			if errors.As(err, &ExitError{}) {
+				return err
+			}
+
+			log.SetOutput(os.Stderr)
+			log.Println(err)
+
+			return exit(1)
+		}
+
+		return nil
+	}
+}
+
+func configShow(args []string, params *configParams) error {
+	l, _, err := configLinter(args, params)
+	if err != nil {
+		return err
+	}
+
+	conf, err := l.GetConfig()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	bs, err := yaml.Marshal(conf)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	_, err = os.Stdout.Write(bs)
+
+	return err //nolint:wrapcheck
+}
+
+func configValidate(args []string, params *configParams) error {
+	l, path, err := configLinter(args, params)
+	if err != nil {
+		return err
+	}
+
+	if path == "" {
+		fmt.Fprintln(os.Stdout, "No configuration file found, using the default configuration")
+
+		return nil
+	}
+
+	problems, err := l.ValidateConfig()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintf(os.Stdout, "No problems found in %s\n", path)
+
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%d problem(s) found in %s:\n\n", len(problems), path)
+
+	for _, problem := range problems {
+		fmt.Fprintln(os.Stdout, problem)
+	}
+
+	return exit(1)
+}
+
+func configExplain(args []string, params *configParams) error {
+	var file string
+	if len(args) > 1 {
+		file = args[1]
+	}
+
+	l, _, err := configLinter(args[1:], params)
+	if err != nil {
+		return err
+	}
+
+	explanation, err := l.WithDisableAll(params.disableAll).
+		WithDisabledCategories(params.disableCategory.v...).
+		WithDisabledRules(params.disable.v...).
+		WithEnableAll(params.enableAll).
+		WithEnabledCategories(params.enableCategory.v...).
+		WithEnabledRules(params.enable.v...).
+		WithIgnore(params.ignoreFiles.v).
+		ExplainRule(context.Background(), args[0], file)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	status := "disabled"
+	if explanation.Enabled {
+		status = "enabled"
+	}
+
+	target := ""
+	if file != "" {
+		target = " for " + file
+	}
+
+	fmt.Fprintf(os.Stdout, "Rule %s/%s is %s%s, with level %s\n",
+		explanation.Category, explanation.Title, status, target, explanation.Level)
+
+	if len(explanation.Reasons) > 0 {
+		fmt.Fprintf(os.Stdout, "\nReasons:\n\n- %s\n", strings.Join(explanation.Reasons, "\n- "))
+	}
+
+	return nil
+}
+
+func configSchema(args []string, params *configParams) error {
+	l, _, err := configLinter(args, params)
+	if err != nil {
+		return err
+	}
+
+	schema, err := l.ConfigSchema()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	bs, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema: %w", err)
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, string(bs))
+
+	return err //nolint:wrapcheck
+}
+
+// configLinter returns a linter with the user configuration and custom rules found from the path in args,
+// or the current working directory, along with the path of the user configuration file, if any.
+func configLinter(args []string, params *configParams) (linter.Linter, string, error) {
+	l := linter.NewLinter().WithDebugMode(params.debug)
+
+	searchPath := getSearchPath(args)
+
+	if regalPath, err := config.FindRegalDirectoryPath(searchPath); err == nil {
+		if params.configFile == "" {
+			if regalConf := filepath.Join(regalPath, "config.yaml"); rio.IsFile(regalConf) {
+				params.configFile = regalConf
+			}
+		}
+
+		if rulesDir := filepath.Join(regalPath, "rules"); !params.rules.isSet && rio.IsDir(rulesDir) {
+			l = l.WithCustomRules([]string{rulesDir})
+		}
+	}
+
+	if params.rules.isSet {
+		l = l.WithCustomRules(params.rules.v)
+	}
+
+	userConfig, path, err := loadUserConfig(params.lintAndFixParams, searchPath)
+	if err != nil {
+		return l, "", fmt.Errorf("failed to read user-provided config: %w", err)
+	}
+
+	if path != "" || params.preset != "" {
+		l = l.WithUserConfig(userConfig)
+	}
+
+	return l, path, nil
+}
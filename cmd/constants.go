@@ -3,6 +3,8 @@ package cmd
 const (
 	// formatJSON is the JSON format value for the --format flag in various commands.
 	formatJSON = "json"
+	// formatJSONLines is the JSON Lines format value for the --format flag in various commands.
+	formatJSONLines = "jsonl"
 	// formatPretty is the pretty format value for the --format flag in various commands.
 	formatPretty = "pretty"
 	// formatCompact is the compact format value for the --format flag in various commands.
@@ -15,4 +17,16 @@ const (
 	formatSarif = "sarif"
 	// formatJunit is the JUnit format value for the --format flag in various commands.
 	formatJunit = "junit"
+	// formatCheckstyle is the Checkstyle format value for the --format flag in various commands.
+	formatCheckstyle = "checkstyle"
+	// formatGitLab is the GitLab Code Quality format value for the --format flag in various commands.
+	formatGitLab = "gitlab"
+	// formatHTML is the HTML format value for the --format flag in various commands.
+	formatHTML = "html"
+	// formatTemplate is the Go text/template format value for the --format flag in various commands.
+	formatTemplate = "template"
+	// formatDiff is the unified diff format value for the --format flag of the fix command.
+	formatDiff = "diff"
+	// formatMarkdown is the Markdown format value for the --format flag in various commands.
+	formatMarkdown = "markdown"
 )
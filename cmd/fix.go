@@ -12,7 +12,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/open-policy-agent/regal/internal/git"
 	rio "github.com/open-policy-agent/regal/internal/io"
@@ -83,9 +82,13 @@ The linter rules with automatic fixes available are currently:
 
 	setCommonFlags(fixCommand, &params.lintAndFixParams)
 
+	fixCommand.Flags().Lookup("format").Usage = fmt.Sprintf("set output format (%s, %s, %s)",
+		formatPretty, formatDiff, formatJSON)
+
 	fixCommand.Flags().BoolVarP(&params.dryRun, "dry-run", "", false,
-		"run the fixer in dry-run mode, use with --verbose to see changes")
-	fixCommand.Flags().BoolVarP(&params.verbose, "verbose", "", false, "show the full changes applied in the console")
+		"run the fixer in dry-run mode, use with --verbose, or --format diff, to see changes")
+	fixCommand.Flags().BoolVarP(&params.verbose, "verbose", "", false,
+		"show the full changes applied in the console, when using the pretty format")
 	fixCommand.Flags().BoolVarP(&params.force, "force", "", false,
 		"allow fixing of files that have uncommitted changes in git or when git is not being used")
 	fixCommand.Flags().StringVarP(&params.conflictMode, "on-conflict", "", "error",
@@ -173,8 +176,12 @@ func fix(args []string, params *fixParams) (err error) {
 			log.Printf("found user config file: %s", userConfigFile.Name())
 		}
 
-		err := yaml.NewDecoder(userConfigFile).Decode(&userConfig)
-		if errors.Is(err, io.EOF) {
+		userConfig, err = config.FromFileWithPreset(userConfigFile, params.preset)
+		if errors.Is(err, io.EOF) && params.preset != "" {
+			if userConfig, err = config.FromPreset(params.preset); err != nil {
+				return err //nolint:wrapcheck
+			}
+		} else if errors.Is(err, io.EOF) {
 			log.Printf("user config file %q is empty, will use the default config", userConfigFile.Name())
 		} else if err != nil {
 			if regalDir != nil {
@@ -184,9 +191,23 @@ func fix(args []string, params *fixParams) (err error) {
 			return fmt.Errorf("failed to decode user config: %w", err)
 		}
 
+		if err := userConfig.LoadCapabilitiesFromBinary(ctx); err != nil {
+			return fmt.Errorf("failed to load capabilities from user config: %w", err)
+		}
+
+		if err := config.AddNestedConfigOverrides(&userConfig, userConfigFile.Name()); err != nil {
+			return err //nolint:wrapcheck
+		}
+
 		l = l.WithUserConfig(userConfig)
 	case params.configFile != "":
 		return fmt.Errorf("user-provided config file not found: %w", err)
+	case params.preset != "":
+		if userConfig, err = config.FromPreset(params.preset); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		l = l.WithUserConfig(userConfig)
 	case params.debug:
 		log.Println("no user-provided config file found, will use the default config")
 	}
@@ -308,7 +329,8 @@ please run fix from a clean state to support the use of git to undo, or use --fo
 		}
 	}
 
-	if params.verbose {
+	// the diff and json formats include the changes, and must not be mixed with other output
+	if params.verbose && params.format == formatPretty {
 		if params.dryRun {
 			fmt.Fprintln(outputWriter, "Dry run mode enabled, the following changes would be made:")
 		}
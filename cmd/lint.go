@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -25,9 +28,14 @@ import (
 	regalmetrics "github.com/open-policy-agent/regal/internal/metrics"
 	"github.com/open-policy-agent/regal/internal/update"
 	"github.com/open-policy-agent/regal/pkg/config"
+	"github.com/open-policy-agent/regal/pkg/fixer"
+	"github.com/open-policy-agent/regal/pkg/fixer/fileprovider"
+	"github.com/open-policy-agent/regal/pkg/fixer/fixes"
 	"github.com/open-policy-agent/regal/pkg/linter"
 	"github.com/open-policy-agent/regal/pkg/report"
 	"github.com/open-policy-agent/regal/pkg/reporter"
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+	"github.com/open-policy-agent/regal/pkg/roast/util"
 	"github.com/open-policy-agent/regal/pkg/version"
 )
 
@@ -35,6 +43,7 @@ type lintAndFixParams struct {
 	configFile      string
 	format          string
 	outputFile      string
+	preset          string
 	rules           repeatedStringFlag
 	disable         repeatedStringFlag
 	disableCategory repeatedStringFlag
@@ -50,11 +59,18 @@ type lintAndFixParams struct {
 type lintParams struct {
 	lintAndFixParams
 
-	failLevel   string
-	enablePrint bool
-	metrics     bool
-	profile     bool
-	instrument  bool
+	failLevel        string
+	shard            string
+	exportAggregates string
+	mergeAggregates  string
+	templateFile     string
+	concurrency      int
+	contextLines     int
+	includeFixes     bool
+	enablePrint      bool
+	metrics          bool
+	profile          bool
+	instrument       bool
 }
 
 func (params *lintAndFixParams) outputWriter() (io.Writer, error) {
@@ -73,8 +89,12 @@ func (params *lintAndFixParams) outputWriter() (io.Writer, error) {
 func setCommonFlags(cmd *cobra.Command, params *lintAndFixParams) {
 	flags := cmd.Flags()
 	flags.StringVarP(&params.configFile, "config-file", "c", "", "set path of configuration file")
+	flags.StringVar(&params.preset, "preset", "",
+		"set configuration preset to use (minimal, recommended, strict, opa-v1-migration), overriding any preset "+
+			"in the configuration file")
 	flags.StringVarP(&params.format, "format", "f", formatPretty,
-		"set output format (pretty, compact, json, github, sarif)")
+		"set output format (pretty, compact, json, jsonl, github, sarif, junit, checkstyle, gitlab, html, markdown, "+
+			"template)")
 	flags.StringVarP(&params.outputFile, "output-file", "o", "",
 		"set file to use for linting output, defaults to stdout")
 	flags.BoolVar(&color.NoColor, "no-color", false, "disable color output")
@@ -108,10 +128,31 @@ func init() {
 		Long:  `Lint Rego source files for linter rule violations.`,
 
 		PreRunE: func(_ *cobra.Command, args []string) error {
-			if len(args) == 0 {
+			if params.mergeAggregates != "" {
+				if len(args) > 0 || params.shard != "" || params.exportAggregates != "" {
+					return errors.New("--merge-aggregates can't be combined with paths, --shard or --export-aggregates")
+				}
+			} else if len(args) == 0 {
 				return errors.New("at least one file or directory must be provided for linting")
 			}
 
+			if params.shard != "" {
+				if _, _, err := parseShard(params.shard); err != nil {
+					return err
+				}
+			} else if params.exportAggregates != "" {
+				// without sharding, aggregate rules are evaluated, and would report again when merging
+				return errors.New("--export-aggregates can only be used with --shard")
+			}
+
+			if (params.format == formatTemplate) != (params.templateFile != "") {
+				return errors.New("--template must be provided when, and only when, --format is template")
+			}
+
+			if params.concurrency < 0 {
+				return errors.New("concurrency must not be negative")
+			}
+
 			return nil
 		},
 
@@ -124,30 +165,7 @@ func init() {
 				return exit(1)
 			}
 
-			errorsFound := 0
-			warningsFound := 0
-
-			for i := range rep.Violations {
-				switch rep.Violations[i].Level {
-				case "error":
-					errorsFound++
-				case "warning":
-					warningsFound++
-				}
-			}
-
-			exitCode := 0
-			if params.failLevel == "error" && errorsFound > 0 {
-				exitCode = 3
-			}
-
-			if params.failLevel == "warning" {
-				if errorsFound > 0 {
-					exitCode = 3
-				} else if warningsFound > 0 {
-					exitCode = 2
-				}
-			}
+			exitCode := exitCodeForViolations(rep.Violations, params.failLevel)
 			if exitCode != 0 {
 				return exit(exitCode)
 			}
@@ -160,14 +178,32 @@ func init() {
 
 	lintCommand.Flags().StringVarP(&params.failLevel, "fail-level", "l", "error",
 		"set level at which to fail with a non-zero exit code (error, warning)")
+	lintCommand.Flags().IntVar(&params.concurrency, "concurrency", 0,
+		"set number of workers to shard files across when linting (default 0, lint all files on a shared worker)")
 	lintCommand.Flags().BoolVar(&params.enablePrint, "enable-print", false, "enable print output from policy")
 	lintCommand.Flags().BoolVar(&params.metrics, "metrics", false,
-		"enable metrics reporting (currently supported only for JSON output format)")
+		"enable metrics reporting (currently supported only for JSON and HTML output formats)")
 	lintCommand.Flags().BoolVar(&params.profile, "profile", false,
-		"enable profiling metrics to be added to reporting (currently supported only for JSON output format)")
+		"enable profiling metrics to be added to reporting (currently supported only for JSON and HTML output formats)")
 	lintCommand.Flags().BoolVar(&params.instrument, "instrument", false,
 		"enable instrumentation metrics to be added to reporting (currently supported only for JSON output format)")
 
+	lintCommand.Flags().BoolVar(&params.includeFixes, "include-fixes", false,
+		"include the edits that regal fix would make to fixable violations in the report")
+	lintCommand.Flags().IntVar(&params.contextLines, "context-lines", reporter.DefaultContextLines,
+		"set number of lines of source code to show before and after each violation in pretty output")
+	lintCommand.Flags().StringVar(&params.templateFile, "template", "",
+		"set path of Go text/template file to render the report with, when using --format template")
+
+	lintCommand.Flags().StringVar(&params.shard, "shard", "",
+		"lint only a shard of the input files, in the form index/total, e.g. 2/8. Aggregate rules are skipped, "+
+			"use --export-aggregates to collect their data for a final --merge-aggregates run")
+	lintCommand.Flags().StringVar(&params.exportAggregates, "export-aggregates", "",
+		"export the report, including data collected for aggregate rules, to the provided file. Requires --shard")
+	lintCommand.Flags().StringVar(&params.mergeAggregates, "merge-aggregates", "",
+		"merge reports exported with --export-aggregates from files matching the provided glob pattern, "+
+			"and run the aggregate rules on the merged data")
+
 	addPprofFlag(lintCommand.Flags())
 
 	RootCommand.AddCommand(lintCommand)
@@ -193,8 +229,23 @@ func lint(args []string, params *lintParams) (result report.Report, err error) {
 		WithProfiling(params.profile).
 		WithInstrumentation(params.instrument).
 		WithInputPaths(args).
+		WithConcurrency(params.concurrency).
 		WithBaseCache(cache.NewBaseCache())
 
+	if params.shard != "" {
+		index, total, err := parseShard(params.shard)
+		if err != nil {
+			return report.Report{}, err
+		}
+
+		regal = regal.WithShard(index, total)
+	}
+
+	if params.exportAggregates != "" {
+		// collect aggregates even if there's only one file to lint in this run
+		regal = regal.WithExportAggregates(true).WithCollectQuery(true)
+	}
+
 	if params.enablePrint {
 		regal = regal.WithPrintHook(topdown.NewPrintHook(os.Stderr))
 	}
@@ -239,9 +290,9 @@ func lint(args []string, params *lintParams) (result report.Report, err error) {
 		m.Timer(regalmetrics.RegalConfigParse).Start()
 	}
 
-	userConfig, path, err := loadUserConfig(params.lintAndFixParams, searchPath)
+	userConfig, _, err := loadUserConfig(params.lintAndFixParams, searchPath)
 	if err != nil {
-		return report.Report{}, fmt.Errorf("failed to read user-provided config in %s: %w", path, err)
+		return report.Report{}, fmt.Errorf("failed to read user-provided config: %w", err)
 	}
 
 	if params.metrics {
@@ -252,24 +303,233 @@ func lint(args []string, params *lintParams) (result report.Report, err error) {
 
 	go updateCheckAndWarn(params, rbundle.LoadedBundle(), &userConfig)
 
+	var shards report.Report
+
+	if params.mergeAggregates != "" {
+		if shards, err = readShardReports(params.mergeAggregates); err != nil {
+			return report.Report{}, err
+		}
+
+		// only the aggregate rules will be evaluated, as there are no input files
+		regal = regal.WithAggregates(shards.Aggregates).WithIgnoreDirectives(shards.IgnoreDirectives)
+	}
+
+	rep, err := getReporter(params, outputWriter)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("failed to get reporter: %w", err)
+	}
+
 	regal, err = regal.Prepare(ctx)
 	if err != nil {
 		return report.Report{}, fmt.Errorf("failed to prepare for linting: %w", err)
 	}
 
+	attachFixes := func([]report.Violation) error { return nil }
+
+	if params.includeFixes {
+		conf, err := regal.GetConfig()
+		if err != nil {
+			return report.Report{}, fmt.Errorf("failed to get configuration: %w", err)
+		}
+
+		attachFixes = func(violations []report.Violation) error {
+			return includeFixes(violations, conf)
+		}
+	}
+
+	if params.mergeAggregates != "" && len(shards.Aggregates) == 0 {
+		// none of the shards collected any aggregates, so there's nothing left to lint
+		if err = attachFixes(shards.Violations); err != nil {
+			return report.Report{}, err
+		}
+
+		shards.Aggregates, shards.IgnoreDirectives = nil, nil
+
+		return shards, rep.Publish(ctx, shards) //nolint:wrapcheck
+	}
+
+	// streaming reporters publish violations per file as soon as they're
+	// available, and only the aggregate violations and summary at the end
+	if streamer, ok := rep.(reporter.StreamingReporter); ok && params.mergeAggregates == "" {
+		result, err = regal.LintStream(ctx, func(file string, violations []report.Violation) error {
+			if err := attachFixes(violations); err != nil {
+				return err
+			}
+
+			return streamer.PublishFile(ctx, file, violations)
+		})
+		if err != nil {
+			return report.Report{}, formatError(params.format, fmt.Errorf("error(s) encountered while linting: %w", err))
+		}
+
+		if err = exportAggregates(ctx, params, &result); err != nil {
+			return report.Report{}, err
+		}
+
+		// fixes for all other violations were attached before they were published per file
+		if err = forAggregateViolations(result.Violations, attachFixes); err != nil {
+			return report.Report{}, err
+		}
+
+		return result, streamer.Finish(ctx, result) //nolint:wrapcheck
+	}
+
 	result, err = regal.Lint(ctx)
 	if err != nil {
 		return report.Report{}, formatError(params.format, fmt.Errorf("error(s) encountered while linting: %w", err))
 	}
 
-	rep, err := getReporter(params.format, outputWriter)
-	if err != nil {
-		return report.Report{}, fmt.Errorf("failed to get reporter: %w", err)
+	if err = exportAggregates(ctx, params, &result); err != nil {
+		return report.Report{}, err
+	}
+
+	if params.mergeAggregates != "" {
+		merged := report.Merge(shards, result)
+		merged.Aggregates, merged.IgnoreDirectives = nil, nil
+		merged.Metrics, merged.Profile = result.Metrics, result.Profile
+
+		result = merged
+	}
+
+	if err = attachFixes(result.Violations); err != nil {
+		return report.Report{}, err
 	}
 
 	return result, rep.Publish(ctx, result) //nolint:wrapcheck
 }
 
+// forAggregateViolations calls fn with only the violations reported by aggregate rules, and
+// updates violations with any changes made to them.
+func forAggregateViolations(violations []report.Violation, fn func([]report.Violation) error) error {
+	indices := make([]int, 0, len(violations))
+	aggregates := make([]report.Violation, 0, len(violations))
+
+	for i := range violations {
+		if violations[i].IsAggregate {
+			indices = append(indices, i)
+			aggregates = append(aggregates, violations[i])
+		}
+	}
+
+	if len(aggregates) == 0 {
+		return nil
+	}
+
+	if err := fn(aggregates); err != nil {
+		return err
+	}
+
+	for j, i := range indices {
+		violations[i] = aggregates[j]
+	}
+
+	return nil
+}
+
+// includeFixes attaches the edits that the default fixes would make to each fixable violation.
+func includeFixes(violations []report.Violation, conf *config.Config) error {
+	f := fixer.NewFixer().RegisterFixes(fixes.NewDefaultFixes()...)
+
+	files := util.NewSet[string]()
+
+	for i := range violations {
+		if _, ok := f.GetFixForName(violations[i].Title); ok {
+			files.Add(violations[i].Location.File)
+		}
+	}
+
+	if files.Size() == 0 {
+		return nil
+	}
+
+	fp, err := fileprovider.NewInMemoryFileProviderFromFS(files.Items()...)
+	if err != nil {
+		return fmt.Errorf("failed to read files to fix: %w", err)
+	}
+
+	if err = f.AttachFixes(violations, fp, conf); err != nil {
+		return fmt.Errorf("failed to include fixes: %w", err)
+	}
+
+	return nil
+}
+
+// parseShard parses a shard in the form "index/total", e.g. "2/8".
+func parseShard(shard string) (index, total int, err error) {
+	i, t, ok := strings.Cut(shard, "/")
+	if ok {
+		index, err = strconv.Atoi(i)
+		if err == nil {
+			total, err = strconv.Atoi(t)
+		}
+	}
+
+	if !ok || err != nil || total < 1 || index < 1 || index > total {
+		return 0, 0, fmt.Errorf("invalid shard %q, expected format index/total, e.g. 2/8", shard)
+	}
+
+	return index, total, nil
+}
+
+// exportAggregates writes the report, including aggregates and ignore directives, to the file
+// provided by the --export-aggregates flag, if set. Aggregates and ignore directives are then
+// removed from the report, as they are not meant for the final output.
+func exportAggregates(ctx context.Context, params *lintParams, result *report.Report) error {
+	if params.exportAggregates == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(params.exportAggregates), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for exported aggregates: %w", err)
+	}
+
+	f, err := os.Create(params.exportAggregates)
+	if err != nil {
+		return fmt.Errorf("failed to create file for exported aggregates: %w", err)
+	}
+
+	defer rio.CloseFileIgnore(f)
+
+	if err = reporter.NewJSONReporter(f).Publish(ctx, *result); err != nil {
+		return fmt.Errorf("failed to write exported aggregates to %s: %w", params.exportAggregates, err)
+	}
+
+	result.Aggregates, result.IgnoreDirectives = nil, nil
+
+	return nil
+}
+
+// readShardReports reads and merges all reports exported by sharded lint runs,
+// and found in files matching the provided glob pattern.
+func readShardReports(pattern string) (report.Report, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("invalid pattern for aggregates to merge %q: %w", pattern, err)
+	}
+
+	if len(paths) == 0 {
+		return report.Report{}, fmt.Errorf("no files found matching %q", pattern)
+	}
+
+	reports := make([]report.Report, 0, len(paths))
+
+	for _, path := range paths {
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return report.Report{}, fmt.Errorf("failed to read exported aggregates: %w", err)
+		}
+
+		var rep report.Report
+		if err = encoding.JSON().Unmarshal(bs, &rep); err != nil {
+			return report.Report{}, fmt.Errorf("failed to unmarshal exported aggregates from %s: %w", path, err)
+		}
+
+		reports = append(reports, rep)
+	}
+
+	return report.Merge(reports...), nil
+}
+
 func updateCheckAndWarn(params *lintParams, regalRules *bundle.Bundle, userConfig *config.Config) {
 	mergedConfig, err := config.LoadConfigWithDefaultsFromBundle(regalRules, userConfig)
 	if err != nil {
@@ -291,14 +551,16 @@ func updateCheckAndWarn(params *lintParams, regalRules *bundle.Bundle, userConfi
 	}
 }
 
-func getReporter(format string, outputWriter io.Writer) (reporter.Reporter, error) {
-	switch format {
+func getReporter(params *lintParams, outputWriter io.Writer) (reporter.Reporter, error) {
+	switch format := params.format; format {
 	case formatPretty:
-		return reporter.NewPrettyReporter(outputWriter), nil
+		return reporter.NewPrettyReporter(outputWriter).WithContextLines(params.contextLines), nil
 	case formatCompact:
 		return reporter.NewCompactReporter(outputWriter), nil
 	case formatJSON:
 		return reporter.NewJSONReporter(outputWriter), nil
+	case formatJSONLines:
+		return reporter.NewJSONLinesReporter(outputWriter), nil
 	case formatGitHub:
 		return reporter.NewGitHubReporter(outputWriter), nil
 	case formatFestive:
@@ -307,6 +569,21 @@ func getReporter(format string, outputWriter io.Writer) (reporter.Reporter, erro
 		return reporter.NewSarifReporter(outputWriter), nil
 	case formatJunit:
 		return reporter.NewJUnitReporter(outputWriter), nil
+	case formatCheckstyle:
+		return reporter.NewCheckstyleReporter(outputWriter), nil
+	case formatGitLab:
+		return reporter.NewGitLabReporter(outputWriter), nil
+	case formatHTML:
+		return reporter.NewHTMLReporter(outputWriter), nil
+	case formatMarkdown:
+		return reporter.NewMarkdownReporter(outputWriter), nil
+	case formatTemplate:
+		tpl, err := reporter.ParseTemplateFile(params.templateFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return reporter.NewTemplateReporter(outputWriter, tpl), nil
 	default:
 		return nil, fmt.Errorf("unknown format %s", format)
 	}
@@ -315,7 +592,17 @@ func getReporter(format string, outputWriter io.Writer) (reporter.Reporter, erro
 func formatError(format string, err error) error {
 	// currently, JSON and SARIF will get the same generic JSON error format
 	switch format {
-	case formatJSON, formatSarif:
+	case formatJSONLines:
+		bs, err := json.Marshal(map[string]any{
+			"type":   "errors",
+			"errors": []string{err.Error()},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to format errors for output: %w", err)
+		}
+
+		return fmt.Errorf("%s", string(bs))
+	case formatJSON, formatSarif, formatGitLab:
 		bs, err := json.MarshalIndent(map[string]any{
 			"errors": []string{err.Error()},
 		}, "", "  ")
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	rio "github.com/open-policy-agent/regal/internal/io"
+	"github.com/open-policy-agent/regal/internal/migrate"
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/config"
+)
+
+type migrateParams struct {
+	to         string
+	configFile string
+	format     string
+	dryRun     bool
+}
+
+// migrateReport is the outcome of migrating the provided paths.
+type migrateReport struct {
+	// Migrated lists the modules rewritten.
+	Migrated []string `json:"migrated"`
+	// Updated lists the .manifest and configuration files updated.
+	Updated []string `json:"updated"`
+	// Failed lists the modules that could not be rewritten as valid Rego v1, and were left unchanged.
+	Failed []string `json:"failed"`
+	// Findings lists the patterns that need manual review.
+	Findings []migrate.Finding `json:"findings"`
+	// Errors lists the files that could not be migrated, and why.
+	Errors []string `json:"errors,omitempty"`
+	DryRun bool     `json:"dry_run,omitempty"`
+}
+
+func init() {
+	params := &migrateParams{}
+
+	migrateCommand := &cobra.Command{
+		Use:   "migrate --to v1 <path> [path [...]]",
+		Short: "Migrate Rego v0 policy to Rego v1",
+		Long: `Migrate Rego v0 policy to Rego v1.
+
+Modules are rewritten to use the if and contains keywords where required, and imports of future.keywords
+and rego.v1 are removed. Patterns that are no longer allowed, or that change meaning, in Rego v1 are reported
+for manual review, like calls to deprecated built-in functions, rules or variables shadowing input or data,
+and keywords of Rego v1 used as names.
+
+A rego_version of 0 in any .manifest file found in the provided directories is updated to 1. So is the
+project.rego-version in the Regal configuration file, when the whole project is migrated, and the
+rego-version of any project roots migrated.
+
+If any module could not be migrated, the Rego versions of the bundles, project and roots where it is found
+are left unchanged, and the modules migrated are rewritten to be compatible with both Rego v0 and v1, by
+importing rego.v1, so that all policy remains loadable. Once the modules reported have been fixed, the
+command may be run again to complete the migration.
+
+Exits with a non-zero exit code if any file could not be migrated.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			if err := migrateTo(os.Stdout, args, params); err != nil {
+				if errors.As(err, &ExitError{}) {
+					return err
+				}
+
+				log.SetOutput(os.Stderr)
+				log.Println(err)
+
+				return exit(1)
+			}
+
+			return nil
+		},
+	}
+
+	flags := migrateCommand.Flags()
+	flags.StringVar(&params.to, "to", "", "set Rego version to migrate to (v1)")
+	flags.StringVarP(&params.configFile, "config-file", "c", "", "set path of configuration file to update")
+	flags.StringVarP(&params.format, "format", "f", formatPretty, "set output format (pretty, json)")
+	flags.BoolVar(&params.dryRun, "dry-run", false, "report what would be migrated without writing any files")
+
+	RootCommand.AddCommand(migrateCommand)
+}
+
+func migrateTo(w io.Writer, args []string, params *migrateParams) error {
+	if params.to != "v1" {
+		return fmt.Errorf("unsupported Rego version '%s', only --to v1 is supported", params.to)
+	}
+
+	if params.format != formatPretty && params.format != formatJSON {
+		return fmt.Errorf("unknown format '%s', expected one of: pretty, json", params.format)
+	}
+
+	paths, err := config.FilterIgnoredPaths(args, nil, true, "")
+	if err != nil {
+		return fmt.Errorf("failed to read policy files: %w", err)
+	}
+
+	report := migrateReport{
+		Migrated: []string{},
+		Updated:  []string{},
+		Failed:   []string{},
+		Findings: []migrate.Finding{},
+		DryRun:   params.dryRun,
+	}
+
+	// all modules are migrated before anything is written, as failures determine what can be
+	var (
+		results  []migrate.Result
+		original = make(map[string]string, len(paths))
+		failed   []string
+	)
+
+	for _, path := range paths {
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			failed = append(failed, path)
+
+			continue
+		}
+
+		result, err := migrate.Module(path, string(bs))
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			failed = append(failed, path)
+
+			continue
+		}
+
+		original[path] = string(bs)
+		report.Findings = append(report.Findings, result.Findings...)
+
+		if result.Failed {
+			report.Failed = append(report.Failed, path)
+			failed = append(failed, path)
+		}
+
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		if !result.Changed {
+			continue
+		}
+
+		// modules must remain loadable as Rego v0 when the Rego versions declared can't all be updated
+		contents := result.Contents
+		if len(failed) > 0 {
+			contents = result.Compatible
+		}
+
+		if contents == original[result.File] {
+			continue
+		}
+
+		if err := writeMigrated(result.File, []byte(contents), params.dryRun); err != nil {
+			report.Errors = append(report.Errors, err.Error())
+
+			continue
+		}
+
+		report.Migrated = append(report.Migrated, result.File)
+	}
+
+	updates, err := migrateMetadataFiles(args, failed, params)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	for path, contents := range updates {
+		if err := writeMigrated(path, contents, params.dryRun); err != nil {
+			report.Errors = append(report.Errors, err.Error())
+
+			continue
+		}
+
+		report.Updated = append(report.Updated, path)
+	}
+
+	slices.Sort(report.Updated)
+
+	if params.format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		fmt.Fprint(w, prettyMigrateReport(report))
+	}
+
+	if len(report.Errors) > 0 || len(report.Failed) > 0 {
+		return exit(1)
+	}
+
+	return nil
+}
+
+// migrateMetadataFiles returns the updated contents of the .manifest files found in the directories
+// provided, and of the Regal configuration file, keyed by path, for those that need to be updated,
+// and that don't declare the Rego version of any of the modules that failed to migrate.
+func migrateMetadataFiles(args, failed []string, params *migrateParams) (map[string][]byte, error) {
+	updates := make(map[string][]byte)
+
+	for _, arg := range args {
+		if info, err := os.Stat(arg); err != nil || !info.IsDir() {
+			continue
+		}
+
+		err := filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || d.Name() != ".manifest" {
+				return err
+			}
+
+			contents, err := migrate.Manifest(path, failed)
+			if contents != nil {
+				updates[path] = contents
+			}
+
+			return err //nolint:wrapcheck
+		})
+		if err != nil {
+			return updates, fmt.Errorf("failed to update .manifest files: %w", err)
+		}
+	}
+
+	configFile := params.configFile
+	if configFile == "" {
+		file, err := config.FindConfig(getSearchPath(args))
+		if err != nil {
+			return updates, nil //nolint:nilerr // no configuration to update
+		}
+
+		configFile = file.Name()
+
+		_ = file.Close()
+
+		// report the file found relative to the current directory, like the paths provided
+		if rel, err := filepath.Rel(rio.Getwd(), configFile); err == nil && !strings.HasPrefix(rel, "..") {
+			configFile = rel
+		}
+	}
+
+	contents, err := migrate.Config(configFile, args, failed)
+	if err != nil {
+		return updates, fmt.Errorf("failed to update configuration: %w", err)
+	}
+
+	if contents != nil {
+		updates[configFile] = contents
+	}
+
+	return updates, nil
+}
+
+func writeMigrated(path string, contents []byte, dryRun bool) error {
+	if dryRun {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, contents, info.Mode()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func prettyMigrateReport(report migrateReport) string {
+	var sb strings.Builder
+
+	verb, updated := "Migrated", "Updated"
+	if report.DryRun {
+		verb, updated = "Would migrate", "Would update"
+	}
+
+	fmt.Fprintf(&sb, "%s %d %s to Rego v1\n", verb, len(report.Migrated), util.Pluralize("file", len(report.Migrated)))
+
+	for _, path := range report.Migrated {
+		fmt.Fprintf(&sb, "- %s\n", path)
+	}
+
+	for _, path := range report.Updated {
+		fmt.Fprintf(&sb, "%s %s\n", updated, path)
+	}
+
+	if len(report.Failed) > 0 {
+		fmt.Fprintf(&sb, "\n%d %s could not be migrated, and Rego versions declared for these were left unchanged:\n",
+			len(report.Failed), util.Pluralize("file", len(report.Failed)))
+
+		for _, path := range report.Failed {
+			fmt.Fprintf(&sb, "- %s\n", path)
+		}
+
+		fmt.Fprintln(&sb, "Files migrated remain compatible with Rego v0, run migrate again once these have been fixed.")
+	}
+
+	if len(report.Findings) > 0 {
+		fmt.Fprintf(&sb, "\n%d %s to review manually:\n", len(report.Findings),
+			util.Pluralize("pattern", len(report.Findings)))
+
+		for _, finding := range report.Findings {
+			fmt.Fprintln(&sb, finding)
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Fprintf(&sb, "\n%d %s:\n", len(report.Errors), util.Pluralize("error", len(report.Errors)))
+
+		for _, err := range report.Errors {
+			fmt.Fprintln(&sb, err)
+		}
+	}
+
+	return sb.String()
+}
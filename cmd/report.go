@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	outil "github.com/open-policy-agent/opa/v1/util"
+
+	"github.com/open-policy-agent/regal/internal/docs"
+	rio "github.com/open-policy-agent/regal/internal/io"
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/report"
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+)
+
+type reportDiffParams struct {
+	format     *outil.EnumFlag
+	outputFile string
+	failLevel  string
+}
+
+func init() {
+	reportCommand := &cobra.Command{
+		Use:   "report",
+		Short: "Work with reports produced by regal lint",
+		Long:  "Work with reports produced by regal lint, like comparing them to each other.",
+	}
+
+	params := &reportDiffParams{
+		format: outil.NewEnumFlag(formatPretty, []string{formatPretty, formatJSON, formatMarkdown}),
+	}
+
+	diffCommand := &cobra.Command{
+		Use:   "diff <old.json> <new.json>",
+		Short: "Compare two JSON reports produced by regal lint",
+		Long: `Compare two JSON reports produced by regal lint --format json, and show which violations
+were introduced, fixed, or unchanged in the new report compared to the old one.
+
+Violations are matched by their fingerprint, allowing them to be tracked even as their location changes.
+
+Exit codes follow those of regal lint, but only take violations introduced in the new report into account.`,
+
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("exactly two reports must be provided for comparison")
+			}
+
+			return nil
+		},
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			diff, err := reportDiff(args, params)
+			if err != nil {
+				log.SetOutput(os.Stderr)
+				log.Println(err)
+
+				return exit(1)
+			}
+
+			if exitCode := exitCodeForViolations(diff.Introduced, params.failLevel); exitCode != 0 {
+				return exit(exitCode)
+			}
+
+			return nil
+		},
+	}
+
+	diffCommand.Flags().VarP(params.format, "format", "f", "set output format (pretty, json, markdown)")
+	diffCommand.Flags().StringVarP(&params.outputFile, "output-file", "o", "",
+		"set file to use for output, defaults to stdout")
+	diffCommand.Flags().StringVarP(&params.failLevel, "fail-level", "l", "error",
+		"set level of introduced violations at which to fail with a non-zero exit code (error, warning)")
+	diffCommand.Flags().BoolVar(&color.NoColor, "no-color", false, "disable color output")
+
+	reportCommand.AddCommand(diffCommand)
+	RootCommand.AddCommand(reportCommand)
+}
+
+func reportDiff(args []string, params *reportDiffParams) (report.Diff, error) {
+	oldReport, err := readReport(args[0])
+	if err != nil {
+		return report.Diff{}, err
+	}
+
+	newReport, err := readReport(args[1])
+	if err != nil {
+		return report.Diff{}, err
+	}
+
+	diff := report.DiffReports(oldReport, newReport)
+
+	var out io.Writer = os.Stdout
+
+	if params.outputFile != "" {
+		f, err := os.Create(params.outputFile)
+		if err != nil {
+			return report.Diff{}, fmt.Errorf("failed to create output file: %w", err)
+		}
+
+		defer rio.CloseFileIgnore(f)
+
+		out = f
+	}
+
+	switch params.format.String() {
+	case formatJSON:
+		err = writeDiffJSON(out, diff)
+	case formatMarkdown:
+		err = writeDiffMarkdown(out, diff)
+	default:
+		err = writeDiffPretty(out, diff)
+	}
+
+	if err != nil {
+		return report.Diff{}, fmt.Errorf("failed to write report diff: %w", err)
+	}
+
+	return diff, nil
+}
+
+func readReport(path string) (report.Report, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("failed to read report: %w", err)
+	}
+
+	var rep report.Report
+	if err = encoding.JSON().Unmarshal(bs, &rep); err != nil {
+		return report.Report{}, fmt.Errorf("failed to unmarshal report from %s: %w", path, err)
+	}
+
+	return rep, nil
+}
+
+// exitCodeForViolations determines the exit code for violations found according to the
+// fail level, where errors result in exit code 3 if level is "error" or "warning", and
+// warnings in 2 if level is "warning".
+func exitCodeForViolations(violations []report.Violation, failLevel string) int {
+	errorsFound, warningsFound := 0, 0
+
+	for i := range violations {
+		switch violations[i].Level {
+		case "error":
+			errorsFound++
+		case "warning":
+			warningsFound++
+		}
+	}
+
+	switch {
+	case (failLevel == "error" || failLevel == "warning") && errorsFound > 0:
+		return 3
+	case failLevel == "warning" && warningsFound > 0:
+		return 2
+	}
+
+	return 0
+}
+
+func writeDiffJSON(out io.Writer, diff report.Diff) error {
+	bs, err := encoding.JSON().MarshalIndent(struct {
+		report.Diff
+
+		Summary map[string]int `json:"summary"`
+	}{
+		Diff: diff,
+		Summary: map[string]int{
+			"introduced": len(diff.Introduced),
+			"fixed":      len(diff.Fixed),
+			"unchanged":  len(diff.Unchanged),
+		},
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json marshalling of report diff failed: %w", err)
+	}
+
+	_, err = fmt.Fprintln(out, outil.ByteSliceToString(bs))
+
+	return err
+}
+
+func writeDiffPretty(out io.Writer, diff report.Diff) error {
+	sb := &strings.Builder{}
+
+	red := color.New(color.FgRed).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	for _, section := range []struct {
+		title      string
+		sign       string
+		colorize   func(...any) string
+		violations []report.Violation
+	}{
+		{title: "Introduced", sign: "+", colorize: red, violations: diff.Introduced},
+		{title: "Fixed", sign: "-", colorize: green, violations: diff.Fixed},
+	} {
+		if len(section.violations) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(sb, "%s (%d):\n", section.title, len(section.violations))
+
+		for _, v := range section.violations { //nolint:gocritic
+			fmt.Fprintf(sb, "%s %s %s/%s: %s (%s)\n",
+				section.colorize(section.sign),
+				cyan(v.Location.String()),
+				v.Category,
+				v.Title,
+				v.Description,
+				v.Level,
+			)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(sb, "%d introduced, %d fixed, %d unchanged %s.\n",
+		len(diff.Introduced), len(diff.Fixed), len(diff.Unchanged),
+		util.Pluralize("violation", len(diff.Introduced)+len(diff.Fixed)+len(diff.Unchanged)),
+	)
+
+	_, err := io.WriteString(out, sb.String())
+
+	return err
+}
+
+func writeDiffMarkdown(out io.Writer, diff report.Diff) error {
+	sb := &strings.Builder{}
+
+	sb.WriteString("### Regal Report Diff\n\n")
+	fmt.Fprintf(sb, "**%d** introduced, **%d** fixed, **%d** unchanged %s.\n",
+		len(diff.Introduced), len(diff.Fixed), len(diff.Unchanged),
+		util.Pluralize("violation", len(diff.Introduced)+len(diff.Fixed)+len(diff.Unchanged)),
+	)
+
+	for _, section := range []struct {
+		title      string
+		violations []report.Violation
+	}{
+		{title: "Introduced", violations: diff.Introduced},
+		{title: "Fixed", violations: diff.Fixed},
+	} {
+		if len(section.violations) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(sb, "\n#### %s\n\n", section.title)
+		sb.WriteString("| Level | Location | Rule | Description |\n")
+		sb.WriteString("| --- | --- | --- | --- |\n")
+
+		for _, v := range section.violations { //nolint:gocritic
+			rule := v.Category + "/" + v.Title
+			if url := docs.ViolationURL(v); url != "" {
+				rule = fmt.Sprintf("[%s](%s)", rule, url)
+			}
+
+			fmt.Fprintf(sb, "| %s | `%s` | %s | %s |\n",
+				v.Level, v.Location.String(), rule, strings.ReplaceAll(v.Description, "|", "\\|"),
+			)
+		}
+	}
+
+	_, err := io.WriteString(out, sb.String())
+
+	return err
+}
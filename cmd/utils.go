@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	rio "github.com/open-policy-agent/regal/internal/io"
@@ -97,14 +99,24 @@ func loadUserConfig(params lintAndFixParams, root string) (cfg config.Config, pa
 	}
 
 	if file == nil {
+		if params.preset != "" {
+			cfg, err = config.FromPreset(params.preset)
+
+			return cfg, "", err //nolint:wrapcheck
+		}
+
 		return config.Config{}, "", nil // No user config provided, use default
 	}
 
 	defer rio.CloseFileIgnore(file)
 
-	cfg, err = config.FromFile(file)
+	cfg, err = config.FromFileWithPreset(file, params.preset)
 	if err != nil {
 		switch {
+		case errors.Is(err, io.EOF) && params.preset != "":
+			if cfg, err = config.FromPreset(params.preset); err != nil {
+				return cfg, "", err //nolint:wrapcheck
+			}
 		case errors.Is(err, io.EOF):
 			log.Printf("user config file %q is empty, will use the default config", file.Name())
 		case params.configFile != "":
@@ -114,6 +126,20 @@ func loadUserConfig(params lintAndFixParams, root string) (cfg config.Config, pa
 		}
 	}
 
+	if err = cfg.LoadCapabilitiesFromBinary(context.Background()); err != nil {
+		return cfg, "", fmt.Errorf("failed to load capabilities from user config: %w", err)
+	}
+
+	if err = config.AddNestedConfigOverrides(&cfg, file.Name()); err != nil {
+		return cfg, "", err //nolint:wrapcheck
+	}
+
+	if params.debug {
+		for _, key := range slices.Sorted(maps.Keys(cfg.Origins)) {
+			log.Printf("config %s set by %s", key, cfg.Origins[key])
+		}
+	}
+
 	return cfg, file.Name(), nil
 }
 
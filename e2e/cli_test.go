@@ -3,6 +3,7 @@
 package e2e
 
 import (
+	"encoding/json"
 	"fmt"
 	"maps"
 	"path/filepath"
@@ -50,6 +51,13 @@ func TestLintEmptyDir(t *testing.T) {
 				contains(`"num_violations": 0`),
 			),
 		},
+		{
+			format: "jsonl",
+			check: equals(
+				`{"type":"summary","summary":{"files_scanned":0,"files_failed":0,"rules_skipped":0,"num_violations":0}}` +
+					"\n",
+			),
+		},
 	} {
 		t.Run(tc.format, regal("lint", "--format", tc.format, t.TempDir()).expectStdout(tc.check).test)
 	}
@@ -176,6 +184,19 @@ func TestLintRuleIgnoreFiles(t *testing.T) {
 	testutil.AssertNotContainsViolations(t, rep, "prefer-snake-case")
 }
 
+func TestLintWithPreset(t *testing.T) {
+	var rep report.Report
+
+	regal("lint", "--format", "json", "--config-file", cwd("e2e_conf.yaml"), "--preset", "minimal",
+		cwd("testdata/violations")).
+		expectExitCode(3).
+		expectStdout(unmarshalsTo(&rep)).
+		verify(t)
+
+	testutil.AssertContainsViolations(t, rep, "constant-condition", "top-level-iteration")
+	testutil.AssertNotContainsViolations(t, rep, "prefer-snake-case", "use-assignment-operator", "todo-comment")
+}
+
 func TestLintWithDebugOption(t *testing.T) {
 	regal("lint", "--debug", "--config-file", cwd("testdata/configs/ignore_files_prefer_snake_case.yaml"),
 		cwd("testdata/violations")).
@@ -269,6 +290,191 @@ func TestLintAggregateIgnoreDirective(t *testing.T) {
 	}
 }
 
+func TestLintIncludeFixes(t *testing.T) {
+	td := testutil.TempDirectoryOf(t, map[string]string{"p.rego": "package p\n\nallow:=true\n"})
+
+	expected := []report.TextEdit{{
+		Start:   report.Position{Row: 3, Column: 1},
+		End:     report.Position{Row: 4, Column: 1},
+		NewText: "allow := true\n",
+	}}
+
+	assertFix := func(t *testing.T, violations []report.Violation) {
+		t.Helper()
+
+		for _, v := range violations {
+			if v.Title != "opa-fmt" {
+				continue
+			}
+
+			if v.Fix == nil || !slices.Equal(v.Fix.Edits, expected) {
+				t.Errorf("expected opa-fmt violation with edits %v, got fix %v", expected, v.Fix)
+			}
+
+			return
+		}
+
+		t.Errorf("expected opa-fmt violation, got %v", violations)
+	}
+
+	var rep, merged report.Report
+
+	r := regal("lint", "--format", "json", "--include-fixes", "p.rego").
+		inDirectory(td).
+		expectExitCode(3).
+		expectStdout(unmarshalsTo(&rep)).
+		verify(t)
+
+	assertFix(t, rep.Violations)
+
+	// streamed violations are published with their fixes
+	var streamed string
+
+	r.regal("lint", "--format", "jsonl", "--include-fixes", "p.rego").
+		inDirectory(td).
+		expectExitCode(3).
+		expectStdout(func(_ *testing.T, _, act string) { streamed = act }).
+		verify(t)
+
+	var violations []report.Violation
+
+	for line := range strings.Lines(streamed) {
+		var l struct {
+			Violation *report.Violation `json:"violation"`
+		}
+
+		if err := json.Unmarshal([]byte(line), &l); err != nil {
+			t.Fatalf("failed to unmarshal line %q: %v", line, err)
+		}
+
+		if l.Violation != nil {
+			violations = append(violations, *l.Violation)
+		}
+	}
+
+	assertFix(t, violations)
+
+	// fixes are attached to the violations of shards when merging, even when not included by the shard
+	r.regal("lint", "--format", "json", "--shard", "1/1", "--export-aggregates", "agg.json", "p.rego").
+		inDirectory(td).
+		expectExitCode(3).
+		expectStdout(notEmpty()).
+		verify(t)
+
+	r.regal("lint", "--format", "json", "--include-fixes", "--merge-aggregates", "agg.json").
+		inDirectory(td).
+		expectExitCode(3).
+		expectStdout(unmarshalsTo(&merged)).
+		verify(t)
+
+	assertFix(t, merged.Violations)
+}
+
+func TestLintExportAggregatesRequiresShard(t *testing.T) {
+	regal("lint", "--export-aggregates", filepath.Join(t.TempDir(), "agg.json"), cwd("testdata/aggregates")).
+		expectExitCode(1).
+		expectStderr(contains("--export-aggregates can only be used with --shard")).
+		verify(t)
+}
+
+func TestLintShardsWithMergedAggregates(t *testing.T) {
+	out := t.TempDir()
+	conf := cwd("e2e_conf.yaml")
+
+	var first, second, merged report.Report
+
+	r := regal("lint", "--format", "json", "--config-file", conf, "--shard", "1/2",
+		"--export-aggregates", filepath.Join(out, "agg-1.json"), cwd("testdata/aggregates/ignore_directive")).
+		expectStdout(unmarshalsTo(&first)).
+		verify(t)
+
+	r.regal("lint", "--format", "json", "--config-file", conf, "--shard", "2/2",
+		"--export-aggregates", filepath.Join(out, "agg-2.json"), cwd("testdata/aggregates/ignore_directive")).
+		expectStdout(unmarshalsTo(&second)).
+		verify(t)
+
+	// aggregate rules are only evaluated when merging
+	for _, rep := range []report.Report{first, second} {
+		testutil.AssertNumViolations(t, 0, rep)
+
+		if rep.Summary.FilesScanned != 1 {
+			t.Errorf("expected 1 file to be scanned in each shard, got %d", rep.Summary.FilesScanned)
+		}
+	}
+
+	r.regal("lint", "--format", "json", "--config-file", conf, "--merge-aggregates", filepath.Join(out, "agg-*.json")).
+		expectExitCode(3).
+		expectStdout(unmarshalsTo(&merged), notContains(`"aggregates"`), notContains(`"ignore_directives"`)).
+		verify(t)
+
+	testutil.AssertNumViolations(t, 2, merged)
+	testutil.AssertOnlyViolations(t, merged, "no-defined-entrypoint", "unresolved-import")
+
+	if merged.Summary.FilesScanned != 2 {
+		t.Errorf("expected 2 files scanned in merged report, got %d", merged.Summary.FilesScanned)
+	}
+
+	if len(merged.Aggregates) > 0 {
+		t.Errorf("expected no aggregates in merged report")
+	}
+}
+
+func TestLintShardsWithoutAggregatesMerged(t *testing.T) {
+	out := t.TempDir()
+	conf := cwd("e2e_conf.yaml")
+
+	var merged report.Report
+
+	// with no aggregate rules enabled, the shards export only the ignore directives of their files
+	r := regal("lint", "--config-file", conf, "--disable-all", "--enable", "prefer-snake-case", "--shard", "1/2",
+		"--export-aggregates", filepath.Join(out, "agg-1.json"), cwd("testdata/aggregates/ignore_directive")).
+		expectStdout(notEmpty()).
+		verify(t)
+
+	exported := testutil.MustReadFile(t, filepath.Join(out, "agg-1.json"))
+	if !strings.Contains(string(exported), `"ignore_directives"`) {
+		t.Fatalf("expected ignore directives to be exported, got %s", exported)
+	}
+
+	r.regal("lint", "--config-file", conf, "--disable-all", "--enable", "prefer-snake-case", "--shard", "2/2",
+		"--export-aggregates", filepath.Join(out, "agg-2.json"), cwd("testdata/aggregates/ignore_directive")).
+		expectStdout(notEmpty()).
+		verify(t)
+
+	r.regal("lint", "--format", "json", "--config-file", conf, "--disable-all", "--enable", "prefer-snake-case",
+		"--merge-aggregates", filepath.Join(out, "agg-*.json")).
+		expectStdout(unmarshalsTo(&merged), notContains(`"aggregates"`), notContains(`"ignore_directives"`)).
+		verify(t)
+
+	if merged.Summary.FilesScanned != 2 {
+		t.Errorf("expected 2 files scanned in merged report, got %d", merged.Summary.FilesScanned)
+	}
+}
+
+func TestReportDiff(t *testing.T) {
+	t.Run("pretty", regal("report", "diff", "--no-color",
+		cwd("testdata/reports/old.json"), cwd("testdata/reports/new.json")).
+		expectStdout(equals(
+			"Introduced (1):\n"+
+				"+ p.rego:9:1 style/line-length: Line too long (warning)\n\n"+
+				"Fixed (1):\n"+
+				"- p.rego:8:2 testing/print-or-trace-call: Call to print or trace function (error)\n\n"+
+				"1 introduced, 1 fixed, 1 unchanged violations.\n",
+		)).
+		test)
+
+	t.Run("fail level warning", regal("report", "diff", "--fail-level", "warning", "--format", "json",
+		cwd("testdata/reports/old.json"), cwd("testdata/reports/new.json")).
+		expectExitCode(2).
+		expectStdout(contains(`"introduced": 1`), contains(`"fixed": 1`), contains(`"unchanged": 1`)).
+		test)
+
+	t.Run("no changes", regal("report", "diff", "--format", "markdown",
+		cwd("testdata/reports/new.json"), cwd("testdata/reports/new.json")).
+		expectStdout(equals("### Regal Report Diff\n\n**0** introduced, **0** fixed, **2** unchanged violations.\n")).
+		test)
+}
+
 func TestTestRegalBundledBundle(t *testing.T) {
 	var res []tester.Result
 
@@ -396,6 +602,78 @@ func TestLintWithCustomCapabilitiesAndUnmetRequirementMultipleFiles(t *testing.T
 		verify(t)
 }
 
+func TestConfigValidate(t *testing.T) {
+	regal("config", "validate", "--config-file", cwd("testdata/configs/invalid.yaml")).
+		expectExitCode(1).
+		expectStdout(
+			contains("2 problem(s) found in"),
+			contains("rules.style.prefer-snake-cas: unknown rule prefer-snake-cas in category style"),
+			contains("rules.style.todo-comment.level: invalid level warn, expected one of error, warning, ignore"),
+		).
+		verify(t)
+}
+
+func TestConfigExplain(t *testing.T) {
+	regal("config", "explain", "--config-file", cwd("testdata/configs/ignore_files_prefer_snake_case.yaml"),
+		"prefer-snake-case", "p.rego").
+		expectStdout(
+			contains("Rule style/prefer-snake-case is disabled for p.rego, with level error"),
+			contains(`- file matches pattern "*.rego" of ignore.files in rule configuration`),
+		).
+		verify(t)
+}
+
+func TestConfigSchema(t *testing.T) {
+	regal("config", "schema", "--config-file", cwd("testdata/configs/ignore_files_prefer_snake_case.yaml")).
+		expectStdout(
+			contains(`"$schema": "http://json-schema.org/draft-07/schema#"`),
+			contains(`"prefer-snake-case": {`),
+			contains(`"max-line-length": {`),
+		).
+		verify(t)
+}
+
+func TestCapabilitiesRequired(t *testing.T) {
+	td := testutil.TempDirectoryOf(t, map[string]string{
+		"p.rego": "package p\n\nimport rego.v1\n\nallow if strings.count(input.name, \"a\") > 1\n",
+	})
+
+	r := regal("capabilities", "required", td).
+		expectStdout(
+			contains("Minimum opa version required: v0.67.0"),
+			contains("- builtin strings.count"),
+		).
+		verify(t)
+
+	r.regal("capabilities", "required", "--format", "json", td).
+		expectStdout(
+			contains(`"minimum_version": "v0.67.0"`),
+			contains(`"name": "rego_v1_import"`),
+		).
+		verify(t)
+}
+
+func TestMigrate(t *testing.T) {
+	td := testutil.TempDirectoryOf(t, map[string]string{
+		".regal/config.yaml": "project:\n  rego-version: 0\n",
+		"p/.manifest":        `{"rego_version": 0}`,
+		"p/p.rego":           "package p\n\nimport future.keywords.in\n\ndeny[x] {\n\tx := any(input.xs)\n}\n",
+	})
+
+	regal("migrate", "--to", "v1", td).
+		expectStdout(
+			contains("Migrated 1 file to Rego v1"),
+			contains("Updated "+filepath.Join(td, ".regal", "config.yaml")),
+			contains("p.rego:6:7: deprecated built-in function calls in expression: any"),
+		).
+		expectFiles(
+			hasContent(filepath.Join(td, "p", "p.rego"), "package p\n\ndeny contains x if {\n\tx := any(input.xs)\n}\n"),
+			hasContent(filepath.Join(td, "p", ".manifest"), `{"rego_version": 1}`),
+			hasContent(filepath.Join(td, ".regal", "config.yaml"), "project:\n  rego-version: 1\n"),
+		).
+		verify(t)
+}
+
 func TestLintPprof(t *testing.T) {
 	// this overrides the ignore directives for e2e loaded from the config file
 	regal("lint", "--ignore-files=none", "--pprof", "clock", cwd("testdata/violations")).
@@ -639,6 +917,47 @@ project:
 		verify(t)
 }
 
+func TestFixDiff(t *testing.T) {
+	initialState := map[string]string{
+		".regal/config.yaml": `
+project:
+  rego-version: 1
+`,
+		"foo/foo.rego": "package foo\n\nallow := true\n",                 // correct, not fixed
+		"foo/bar.rego": "package bar\n\nallow if {\n   input.admin\n}\n", // moved to bar/bar.rego, and formatted
+	}
+	td := testutil.TempDirectoryOf(t, initialState)
+
+	r := regal("fix", "--dry-run", "--format", "diff", ".").
+		inDirectory(td).
+		expectStdout(equals(`diff --git a/foo/bar.rego b/bar/bar.rego
+rename from foo/bar.rego
+rename to bar/bar.rego
+--- a/foo/bar.rego
++++ b/bar/bar.rego
+@@ -1,5 +1,5 @@
+ package bar
+ 
+ allow if {
+-   input.admin
++	input.admin
+ }
+`)).
+		expectFiles(contentMatchesMap(td, initialState)).
+		verify(t)
+
+	r.regal("fix", "--dry-run", "--format", "json", ".").
+		inDirectory(td).
+		expectStdout(
+			contains(`"file": "bar/bar.rego"`),
+			contains(`"old_path": "foo/bar.rego"`),
+			contains(`"total_fixes": 2`),
+			contains(`"dry_run": true`),
+		).
+		expectFiles(contentMatchesMap(td, initialState)).
+		verify(t)
+}
+
 // verify fix for https://github.com/open-policy-agent/regal/issues/1082
 func TestLintAnnotationCustomAttributeMultipleItems(t *testing.T) {
 	regal("lint", "--config-file", cwd("e2e_conf.yaml"), "--disable=directory-package-mismatch",
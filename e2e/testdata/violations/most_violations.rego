@@ -256,6 +256,10 @@ unnecessary_some if {
 	some "x" in ["x"]
 }
 
+# unused-ignore-directive
+# regal ignore:unknown-rule-name
+unused_ignore_directive := true
+
 yoda_condition if {
 	"foo" == input.bar
 }
@@ -3,16 +3,21 @@
 package capabilities
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
 	"path"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/coreos/go-semver/semver"
 
@@ -28,8 +33,24 @@ const (
 	DefaultURL = "regal:///capabilities/default"
 )
 
+// execTimeout is the time allowed for an OPA binary to print its capabilities.
+const execTimeout = 10 * time.Second
+
 var driveLetterPattern = regexp.MustCompile(`^\/[a-zA-Z]:`)
 
+// execCache holds the capabilities loaded from OPA binaries, keyed by path, to avoid running a
+// binary each time config is loaded. Entries are only used while the binary is left unchanged.
+var execCache = struct {
+	sync.Mutex
+	entries map[string]execCacheEntry
+}{entries: make(map[string]execCacheEntry)}
+
+type execCacheEntry struct {
+	modTime time.Time
+	size    int64
+	caps    *ast.Capabilities
+}
+
 // Lookup attempts to retrieve capabilities from the requested RFC3986
 // compliant URL.
 //
@@ -49,7 +70,19 @@ var driveLetterPattern = regexp.MustCompile(`^\/[a-zA-Z]:`)
 // valid semver strings.
 //
 // 'regal://capabilities/{engine}/{version}' loads the requested capabilities
-// version for the specified engine.
+// version for the specified engine. The version may also be a range of versions,
+// like '>=0.60 <1.0', in which case the capabilities common to all versions in
+// the range are loaded, as determined by Intersect().
+//
+// If the URL scheme is 'file' and the path is a directory, the capabilities common
+// to all JSON files in the directory are loaded. A 'version' query parameter may
+// be used to only consider the files whose names, without the .json extension, are
+// versions in the range provided.
+//
+// If the URL scheme is 'exec', the path is taken to be that of an OPA binary, from
+// which the capabilities are loaded using 'opa capabilities --current'. The binary
+// is only run once for as long as it is left unchanged, and must finish within 10
+// seconds.
 func Lookup(ctx context.Context, rawURL string) (*ast.Capabilities, error) {
 	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
@@ -59,6 +92,11 @@ func Lookup(ctx context.Context, rawURL string) (*ast.Capabilities, error) {
 	return LookupURL(ctx, parsedURL)
 }
 
+// IsExecURL returns true if looking up capabilities from the URL provided runs an OPA binary.
+func IsExecURL(rawURL string) bool {
+	return strings.HasPrefix(rawURL, "exec://")
+}
+
 // LookupURL behaves identically to Lookup(), but allows using a pre-parsed
 // URL to avoid a needless round-trip through a string.
 func LookupURL(ctx context.Context, parsedURL *url.URL) (*ast.Capabilities, error) {
@@ -69,6 +107,8 @@ func LookupURL(ctx context.Context, parsedURL *url.URL) (*ast.Capabilities, erro
 		return lookupWebURL(ctx, parsedURL)
 	case "file":
 		return lookupFileURL(parsedURL)
+	case "exec":
+		return lookupExecURL(ctx, parsedURL)
 	case "regal":
 		return lookupEmbeddedURL(parsedURL)
 	default:
@@ -171,6 +211,10 @@ func lookupEmbeddedURL(parsedURL *url.URL) (*ast.Capabilities, error) {
 		version = versionsForEngine[0]
 	}
 
+	if IsVersionRange(version) {
+		return lookupEmbeddedRange(engine, version)
+	}
+
 	switch engine {
 	case engineOPA:
 		// This obtuse error handling is required to make the linter
@@ -193,18 +237,100 @@ func lookupEmbeddedURL(parsedURL *url.URL) (*ast.Capabilities, error) {
 	}
 }
 
+// lookupEmbeddedRange loads the capabilities common to all versions of the engine within the range.
+func lookupEmbeddedRange(engine, version string) (*ast.Capabilities, error) {
+	vr, err := ParseVersionRange(version)
+	if err != nil {
+		return nil, err
+	}
+
+	versionsList, err := List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions for engine '%s': %w", engine, err)
+	}
+
+	versions := vr.Filter(versionsList[engine])
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no capabilities found for engine '%s' with version in range '%s'", engine, version)
+	}
+
+	caps := make([]*ast.Capabilities, 0, len(versions))
+
+	for _, v := range versions {
+		c, err := lookupEmbeddedURL(&url.URL{Scheme: "regal", Path: "/capabilities/" + engine + "/" + v})
+		if err != nil {
+			return nil, err
+		}
+
+		caps = append(caps, c)
+	}
+
+	return Intersect(caps...), nil
+}
+
 func lookupFileURL(parsedURL *url.URL) (*ast.Capabilities, error) {
-	// the provided URL's path could be either a windows path or a unix one
-	// we must account for both cases by stripping the leading / if found
-	path := parsedURL.Path
-	if driveLetterPattern.MatchString(path) {
-		path = path[1:]
+	path := localPath(parsedURL)
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return lookupDirectory(path, parsedURL.Query().Get("version"))
+	}
+
+	return loadCapabilitiesFile(path)
+}
+
+// lookupDirectory loads the capabilities common to all JSON files in the directory, or if a version
+// range is provided, to those files whose names are versions in the range.
+func lookupDirectory(dir, version string) (*ast.Capabilities, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory '%s': %w", dir, err)
 	}
 
+	names := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+		}
+	}
+
+	if version != "" {
+		vr, err := ParseVersionRange(version)
+		if err != nil {
+			return nil, err
+		}
+
+		names = vr.Filter(names)
+	} else {
+		// files are intersected from the oldest version, when named by version
+		semverSort(names)
+		slices.Reverse(names)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no capabilities files found in directory '%s'", dir)
+	}
+
+	caps := make([]*ast.Capabilities, 0, len(names))
+
+	for _, name := range names {
+		c, err := loadCapabilitiesFile(filepath.Join(dir, name+".json"))
+		if err != nil {
+			return nil, err
+		}
+
+		caps = append(caps, c)
+	}
+
+	return Intersect(caps...), nil
+}
+
+func loadCapabilitiesFile(path string) (*ast.Capabilities, error) {
 	fd, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("error opening file '%s': %w", path, err)
 	}
+	defer fd.Close()
 
 	caps, err := ast.LoadCapabilitiesJSON(fd)
 	if err != nil {
@@ -214,6 +340,61 @@ func lookupFileURL(parsedURL *url.URL) (*ast.Capabilities, error) {
 	return caps, nil
 }
 
+// lookupExecURL loads capabilities from the OPA binary at the path of the URL.
+func lookupExecURL(ctx context.Context, parsedURL *url.URL) (*ast.Capabilities, error) {
+	path := localPath(parsedURL)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find OPA binary: %w", err)
+	}
+
+	execCache.Lock()
+	defer execCache.Unlock()
+
+	if entry, ok := execCache.entries[path]; ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		return entry.caps, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, path, "capabilities", "--current")
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("failed to run '%s capabilities --current': %w", path, ctx.Err())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to run '%s capabilities --current': %w: %s", path, err, stderr.String())
+	}
+
+	caps, err := ast.LoadCapabilitiesJSON(bytes.NewReader(out))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load capabilities from output of '%s': %w", path, err)
+	}
+
+	execCache.entries[path] = execCacheEntry{modTime: info.ModTime(), size: info.Size(), caps: caps}
+
+	return caps, nil
+}
+
+// localPath returns the path of a file:// or exec:// URL on the local filesystem.
+func localPath(parsedURL *url.URL) string {
+	// the provided URL's path could be either a windows path or a unix one
+	// we must account for both cases by stripping the leading / if found
+	path := parsedURL.Path
+	if driveLetterPattern.MatchString(path) {
+		path = path[1:]
+	}
+
+	return path
+}
+
 func lookupWebURL(ctx context.Context, parsedURL *url.URL) (*ast.Capabilities, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
 	if err != nil {
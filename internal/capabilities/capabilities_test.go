@@ -1,7 +1,10 @@
 package capabilities
 
 import (
+	"net/url"
+	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -48,6 +51,133 @@ func TestLookupFromEmbedded(t *testing.T) {
 	}
 }
 
+func TestLookupFromEmbeddedRange(t *testing.T) {
+	t.Parallel()
+
+	caps, err := Lookup(t.Context(), "regal:///capabilities/opa/"+url.PathEscape(">=0.55 <=0.60"))
+	if err != nil {
+		t.Fatalf("unexpected error from Lookup: %v", err)
+	}
+
+	// the builtins of v0.55.0, as none have been removed since
+	if len(caps.Builtins) != 193 {
+		t.Errorf("OPA >=0.55 <=0.60 capabilities should have 193 builtins, not %d", len(caps.Builtins))
+	}
+
+	if _, err := Lookup(t.Context(), "regal:///capabilities/opa/"+url.PathEscape(">=100.0")); err == nil {
+		t.Errorf("expected error when no versions match the range")
+	}
+}
+
+func TestLookupFromDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for name, contents := range map[string]string{
+		"v1.0.0.json": `{"builtins": [{"name": "a"}, {"name": "b"}], "future_keywords": ["in"]}`,
+		"v1.1.0.json": `{"builtins": [{"name": "a"}, {"name": "b"}, {"name": "c"}], "future_keywords": ["in"]}`,
+		"v2.0.0.json": `{"builtins": [{"name": "a"}, {"name": "c"}], "future_keywords": ["in"]}`,
+		"README.md":   "not capabilities",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	caps, err := Lookup(t.Context(), "file://"+dir)
+	if err != nil {
+		t.Fatalf("unexpected error from Lookup: %v", err)
+	}
+
+	if len(caps.Builtins) != 1 || caps.Builtins[0].Name != "a" {
+		t.Errorf("expected only builtin 'a' common to all files, got %v", caps.Builtins)
+	}
+
+	caps, err = Lookup(t.Context(), "file://"+dir+"?version="+url.QueryEscape("<2.0"))
+	if err != nil {
+		t.Fatalf("unexpected error from Lookup: %v", err)
+	}
+
+	if len(caps.Builtins) != 2 {
+		t.Errorf("expected builtins 'a' and 'b' common to files in range, got %v", caps.Builtins)
+	}
+}
+
+func TestLookupFromBinary(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell script in place of an OPA binary")
+	}
+
+	path := filepath.Join(t.TempDir(), "opa")
+	script := `#!/bin/sh
+[ "$1 $2" = "capabilities --current" ] || exit 1
+cat ` + filepath.Join(mustAbs(t, "testdata"), "capabilities.json") + "\n"
+
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	caps, err := Lookup(t.Context(), "exec://"+path)
+	if err != nil {
+		t.Fatalf("unexpected error from Lookup: %v", err)
+	}
+
+	if len(caps.Builtins) != 1 || caps.Builtins[0].Name != "unittest123" {
+		t.Errorf("expected the builtin of the capabilities printed by the binary, got %v", caps.Builtins)
+	}
+
+	if _, err := Lookup(t.Context(), "exec://"+filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("expected error when binary is missing")
+	}
+}
+
+func TestLookupFromBinaryRunsUnchangedBinaryOnce(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell script in place of an OPA binary")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "opa")
+	script := `#!/bin/sh
+echo run >> ` + filepath.Join(dir, "runs") + `
+cat ` + filepath.Join(mustAbs(t, "testdata"), "capabilities.json") + "\n"
+
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if _, err := Lookup(t.Context(), "exec://"+path); err != nil {
+			t.Fatalf("unexpected error from Lookup: %v", err)
+		}
+	}
+
+	runs, err := os.ReadFile(filepath.Join(dir, "runs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp, got := "run\n", string(runs); exp != got {
+		t.Errorf("expected binary to be run once, got runs %q", got)
+	}
+}
+
+func mustAbs(t *testing.T, path string) string {
+	t.Helper()
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return abs
+}
+
 func TestSemverSort(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,157 @@
+package capabilities
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+)
+
+// Construct is a built-in function, future keyword or feature required by policy.
+type Construct struct {
+	// Kind is one of "builtin", "keyword" or "feature".
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// Requirement describes the minimum version of an engine able to run policy.
+type Requirement struct {
+	Engine string `json:"engine"`
+	// MinimumVersion is the lowest version of the engine supporting all constructs required,
+	// or empty if no known version does.
+	MinimumVersion string `json:"minimum_version,omitempty"`
+	// Required holds all constructs required by the policy.
+	Required []Construct `json:"required"`
+	// Drivers holds the constructs not supported by the version preceding the minimum version,
+	// i.e. those that prevent an older version from being used.
+	Drivers []Construct `json:"drivers"`
+	// Unsupported holds the constructs not supported by the latest version, if no known version
+	// supports all constructs required.
+	Unsupported []Construct `json:"unsupported,omitempty"`
+}
+
+func (c Construct) String() string {
+	return c.Kind + " " + c.Name
+}
+
+// Required returns the built-in functions, future keywords and features used by the policy files provided,
+// keyed by path, as determined by the BuildRequiredCapabilities stage of the OPA compiler. Files are parsed
+// as Rego v0 when possible, and as Rego v1 otherwise, as files compatible with both, like those importing
+// rego.v1, can be run by versions of OPA older than v1.0.0.
+func Required(files map[string]string) (*ast.Capabilities, error) {
+	modules := make(map[string]*ast.Module, len(files))
+
+	for name, contents := range files {
+		module, err := ast.ParseModuleWithOpts(name, contents, ast.ParserOptions{RegoVersion: ast.RegoV0})
+		if err != nil {
+			module, err = ast.ParseModuleWithOpts(name, contents, ast.ParserOptions{RegoVersion: ast.RegoV1})
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+		}
+
+		modules[name] = module
+	}
+
+	compiler := ast.NewCompiler().WithCapabilities(ast.CapabilitiesForThisVersion())
+
+	if compiler.Compile(modules); compiler.Failed() {
+		return nil, fmt.Errorf("failed to compile modules: %w", compiler.Errors)
+	}
+
+	return compiler.Required, nil
+}
+
+// Constructs returns the constructs of the capabilities provided, as required by policy.
+func Constructs(required *ast.Capabilities) []Construct {
+	constructs := make([]Construct, 0, len(required.Builtins)+len(required.FutureKeywords)+len(required.Features))
+
+	for _, feature := range required.Features {
+		constructs = append(constructs, Construct{Kind: "feature", Name: feature})
+	}
+
+	for _, keyword := range required.FutureKeywords {
+		constructs = append(constructs, Construct{Kind: "keyword", Name: keyword})
+	}
+
+	for _, builtin := range required.Builtins {
+		constructs = append(constructs, Construct{Kind: "builtin", Name: builtin.Name})
+	}
+
+	return constructs
+}
+
+// MinimumVersion returns the lowest version of the engine, among the versions of which capabilities are
+// embedded in Regal, supporting all the constructs required. Pre-release versions are not considered.
+func MinimumVersion(engine string, required []Construct) (*Requirement, error) {
+	versionsList, err := List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions for engine '%s': %w", engine, err)
+	}
+
+	// an empty range contains all versions, sorted ascending
+	versions := VersionRange(nil).Filter(versionsList[engine])
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no capabilities found for engine '%s'", engine)
+	}
+
+	requirement := &Requirement{Engine: engine, Required: required, Drivers: []Construct{}}
+
+	var previous *ast.Capabilities
+
+	for _, version := range versions {
+		caps, err := lookupEmbeddedURL(&url.URL{Scheme: "regal", Path: "/capabilities/" + engine + "/" + version})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(unsupported(caps, required)) == 0 {
+			requirement.MinimumVersion = version
+
+			if previous != nil {
+				requirement.Drivers = unsupported(previous, required)
+			}
+
+			return requirement, nil
+		}
+
+		previous = caps
+	}
+
+	requirement.Unsupported = unsupported(previous, required)
+
+	return requirement, nil
+}
+
+// unsupported returns the constructs not supported by the capabilities.
+func unsupported(caps *ast.Capabilities, required []Construct) []Construct {
+	builtins := make(map[string]struct{}, len(caps.Builtins))
+	for _, builtin := range caps.Builtins {
+		builtins[builtin.Name] = struct{}{}
+	}
+
+	// keywords and features of Rego v0 are implied by support for Rego v1
+	regoV1 := slices.Contains(caps.Features, ast.FeatureRegoV1)
+
+	result := make([]Construct, 0)
+
+	for _, c := range required {
+		var ok bool
+
+		switch c.Kind {
+		case "builtin":
+			_, ok = builtins[c.Name]
+		case "keyword":
+			ok = regoV1 || slices.Contains(caps.FutureKeywords, c.Name)
+		default:
+			ok = regoV1 || slices.Contains(caps.Features, c.Name)
+		}
+
+		if !ok {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,79 @@
+package capabilities
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMinimumVersion(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		note    string
+		files   map[string]string
+		minimum string
+		drivers []Construct
+	}{
+		{
+			note:    "builtin introduced after rego.v1 import",
+			files:   map[string]string{"p.rego": "package p\n\nimport rego.v1\n\nx := strings.count(\"a\", \"b\")\n"},
+			minimum: "v0.67.0",
+			drivers: []Construct{{Kind: "builtin", Name: "strings.count"}},
+		},
+		{
+			note:    "rego.v1 import",
+			files:   map[string]string{"p.rego": "package p\n\nimport rego.v1\n\nallow if input.x\n"},
+			minimum: "v0.59.0",
+			drivers: []Construct{{Kind: "feature", Name: "rego_v1_import"}},
+		},
+		{
+			note:    "rego v1 only",
+			files:   map[string]string{"p.rego": "package p\n\nallow if input.x\n"},
+			minimum: "v1.0.0",
+			drivers: []Construct{{Kind: "feature", Name: "rego_v1"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.note, func(t *testing.T) {
+			t.Parallel()
+
+			required, err := Required(tc.files)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			requirement, err := MinimumVersion("opa", Constructs(required))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if requirement.MinimumVersion != tc.minimum {
+				t.Errorf("expected minimum version %s, got %s", tc.minimum, requirement.MinimumVersion)
+			}
+
+			if !slices.Equal(requirement.Drivers, tc.drivers) {
+				t.Errorf("expected drivers %v, got %v", tc.drivers, requirement.Drivers)
+			}
+		})
+	}
+}
+
+func TestMinimumVersionUnsupported(t *testing.T) {
+	t.Parallel()
+
+	required := []Construct{{Kind: "builtin", Name: "count"}, {Kind: "builtin", Name: "acme.custom"}}
+
+	requirement, err := MinimumVersion("opa", required)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requirement.MinimumVersion != "" {
+		t.Errorf("expected no minimum version, got %s", requirement.MinimumVersion)
+	}
+
+	if exp := []Construct{{Kind: "builtin", Name: "acme.custom"}}; !slices.Equal(requirement.Unsupported, exp) {
+		t.Errorf("expected unsupported %v, got %v", exp, requirement.Unsupported)
+	}
+}
@@ -0,0 +1,177 @@
+package capabilities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+)
+
+// VersionRange is a range of versions, like ">=0.60 <1.0", where each space (or comma) separated
+// constraint must be satisfied by a version for it to be in the range.
+type VersionRange []versionConstraint
+
+type versionConstraint struct {
+	version *semver.Version
+	op      string
+}
+
+var rangeOperators = []string{">=", "<=", ">", "<", "="}
+
+// IsVersionRange reports whether s is a range of versions rather than a single version, i.e.
+// whether it contains any comparison operators, or more than one constraint.
+func IsVersionRange(s string) bool {
+	return strings.ContainsAny(s, "<>=") || len(strings.FieldsFunc(s, isRangeSeparator)) > 1
+}
+
+// ParseVersionRange parses a range of versions, like ">=0.60 <1.0". Versions may be prefixed
+// with "v", and missing minor or patch versions are taken to be 0.
+func ParseVersionRange(s string) (VersionRange, error) {
+	fields := strings.FieldsFunc(s, isRangeSeparator)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid version range '%s': no constraints found", s)
+	}
+
+	vr := make(VersionRange, 0, len(fields))
+
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		// allow a space between operator and version, as in ">= 0.60"
+		if slices.Contains(rangeOperators, field) && i+1 < len(fields) {
+			i++
+			field += fields[i]
+		}
+
+		op := "="
+
+		for _, candidate := range rangeOperators {
+			if strings.HasPrefix(field, candidate) {
+				op = candidate
+				field = field[len(candidate):]
+
+				break
+			}
+		}
+
+		version, err := parseVersion(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range '%s': %w", s, err)
+		}
+
+		vr = append(vr, versionConstraint{op: op, version: version})
+	}
+
+	return vr, nil
+}
+
+// Contains reports whether the version is within the range.
+func (vr VersionRange) Contains(version *semver.Version) bool {
+	for _, c := range vr {
+		var ok bool
+
+		switch c.op {
+		case ">=":
+			ok = !version.LessThan(*c.version)
+		case "<=":
+			ok = !c.version.LessThan(*version)
+		case ">":
+			ok = c.version.LessThan(*version)
+		case "<":
+			ok = version.LessThan(*c.version)
+		default:
+			ok = version.Equal(*c.version)
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Filter returns the versions within the range, sorted ascending. Versions that are not valid
+// semver, like those of development builds, are skipped.
+func (vr VersionRange) Filter(versions []string) []string {
+	parsed := make(map[string]*semver.Version, len(versions))
+
+	for _, v := range versions {
+		if version, err := parseVersion(v); err == nil && version.PreRelease == "" && vr.Contains(version) {
+			parsed[v] = version
+		}
+	}
+
+	matching := make([]string, 0, len(parsed))
+	for v := range parsed {
+		matching = append(matching, v)
+	}
+
+	slices.SortFunc(matching, func(a, b string) int {
+		return parsed[a].Compare(*parsed[b])
+	})
+
+	return matching
+}
+
+// Intersect returns the capabilities common to all the capabilities provided, i.e. the built-in
+// functions, future keywords and features supported by all of them. This is the lowest common
+// denominator of a set of OPA versions. Declarations of built-in functions, and any other values,
+// are taken from the first capabilities provided, which should thus be those of the oldest version.
+func Intersect(caps ...*ast.Capabilities) *ast.Capabilities {
+	if len(caps) == 0 {
+		return &ast.Capabilities{}
+	}
+
+	result := *caps[0]
+	result.Builtins = slices.Clone(result.Builtins)
+	result.FutureKeywords = slices.Clone(result.FutureKeywords)
+	result.Features = slices.Clone(result.Features)
+
+	for _, other := range caps[1:] {
+		names := make(map[string]struct{}, len(other.Builtins))
+		for _, builtin := range other.Builtins {
+			names[builtin.Name] = struct{}{}
+		}
+
+		result.Builtins = slices.DeleteFunc(result.Builtins, func(builtin *ast.Builtin) bool {
+			_, ok := names[builtin.Name]
+
+			return !ok
+		})
+
+		result.FutureKeywords = slices.DeleteFunc(result.FutureKeywords, func(keyword string) bool {
+			return !slices.Contains(other.FutureKeywords, keyword)
+		})
+
+		result.Features = slices.DeleteFunc(result.Features, func(feature string) bool {
+			return !slices.Contains(other.Features, feature)
+		})
+	}
+
+	return &result
+}
+
+func parseVersion(s string) (*semver.Version, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	// allow partial versions like 0.60 or 1
+	if core, _, _ := strings.Cut(s, "-"); strings.Count(core, ".") < 2 {
+		rest := strings.TrimPrefix(s, core)
+		s = core + strings.Repeat(".0", 2-strings.Count(core, ".")) + rest
+	}
+
+	version, err := semver.NewVersion(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version '%s': %w", s, err)
+	}
+
+	return version, nil
+}
+
+func isRangeSeparator(r rune) bool {
+	return r == ' ' || r == ','
+}
@@ -0,0 +1,96 @@
+package capabilities
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+)
+
+func TestParseVersionRange(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		note     string
+		input    string
+		versions []string
+		expect   []string
+	}{
+		{
+			note:     "lower and upper bound",
+			input:    ">=0.60 <1.0",
+			versions: []string{"v1.0.0", "v0.70.0", "v0.60.0", "v0.59.0", "v0.61.0-dev"},
+			expect:   []string{"v0.60.0", "v0.70.0"},
+		},
+		{
+			note:     "comma separated with spaces after operators",
+			input:    "> 0.60, <= 0.70",
+			versions: []string{"v0.70.0", "v0.60.0", "v0.65.1"},
+			expect:   []string{"v0.65.1", "v0.70.0"},
+		},
+		{
+			note:     "exact version",
+			input:    "=v1.2.3",
+			versions: []string{"v1.2.3", "v1.2.4", "edge"},
+			expect:   []string{"v1.2.3"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.note, func(t *testing.T) {
+			t.Parallel()
+
+			vr, err := ParseVersionRange(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := vr.Filter(tc.versions); !slices.Equal(got, tc.expect) {
+				t.Errorf("expected %v, got %v", tc.expect, got)
+			}
+		})
+	}
+}
+
+func TestParseVersionRangeInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", ">=", ">=abc <1.0"} {
+		if _, err := ParseVersionRange(input); err == nil {
+			t.Errorf("expected error for '%s'", input)
+		}
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	t.Parallel()
+
+	older := &ast.Capabilities{
+		Builtins:       []*ast.Builtin{{Name: "count"}, {Name: "removed"}},
+		FutureKeywords: []string{"in"},
+		Features:       []string{"a"},
+	}
+	newer := &ast.Capabilities{
+		Builtins:       []*ast.Builtin{{Name: "count"}, {Name: "added"}},
+		FutureKeywords: []string{"in", "every"},
+		Features:       []string{"a", "b"},
+	}
+
+	caps := Intersect(older, newer)
+
+	if len(caps.Builtins) != 1 || caps.Builtins[0].Name != "count" {
+		t.Errorf("expected only the count builtin, got %v", caps.Builtins)
+	}
+
+	if !slices.Equal(caps.FutureKeywords, []string{"in"}) {
+		t.Errorf("expected future keywords [in], got %v", caps.FutureKeywords)
+	}
+
+	if !slices.Equal(caps.Features, []string{"a"}) {
+		t.Errorf("expected features [a], got %v", caps.Features)
+	}
+
+	if len(older.Builtins) != 2 {
+		t.Errorf("expected capabilities provided to be left unchanged")
+	}
+}
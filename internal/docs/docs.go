@@ -1,8 +1,21 @@
 package docs
 
+import "github.com/open-policy-agent/regal/pkg/report"
+
 const docsBaseURL = "https://docs.styra.com/regal/rules"
 
 // CreateDocsURL creates a complete URL to the documentation for a rule.
 func CreateDocsURL(category, title string) string {
 	return docsBaseURL + "/" + category + "/" + title
 }
+
+// ViolationURL returns the URL of the documentation of the rule reporting the violation, if any.
+func ViolationURL(violation report.Violation) string {
+	for _, resource := range violation.RelatedResources {
+		if resource.Description == "documentation" {
+			return resource.Reference
+		}
+	}
+
+	return ""
+}
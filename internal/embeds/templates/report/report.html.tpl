@@ -0,0 +1,113 @@
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Regal Lint Report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto;
+  max-width: 1100px; padding: 0 1em; color: #1f2328; }
+h1, h2, h3 { font-weight: 600; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #d0d7de; padding: 0.3em 0.8em; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+td.num { text-align: right; }
+.summary { display: flex; gap: 2em; flex-wrap: wrap; }
+.level { border-radius: 1em; color: #fff; font-size: 0.8em; padding: 0.1em 0.6em; }
+.level-error { background: #cf222e; }
+.level-warning { background: #9a6700; }
+.violation { border: 1px solid #d0d7de; border-radius: 6px; margin-bottom: 1em; padding: 0.5em 1em; }
+.violation h3 { margin: 0.3em 0; }
+.location { font-family: monospace; }
+pre { background: #f6f8fa; border-radius: 6px; overflow-x: auto; padding: 0.5em 0; }
+pre span { display: block; padding: 0 1em; }
+pre span.highlighted { background: #fff8c5; }
+pre .line-number { color: #656d76; display: inline-block; margin-right: 1em; text-align: right; user-select: none;
+  width: 3em; }
+</style>
+</head>
+<body>
+<h1>Regal Lint Report</h1>
+<p>
+{{- .Summary.FilesScanned }} {{ pluralize "file" .Summary.FilesScanned }} linted.
+{{- if eq .Summary.NumViolations 0 }} No violations found.
+{{- else }} {{ .Summary.NumViolations }} {{ pluralize "violation" .Summary.NumViolations }} found in
+{{- " " }}{{ .Summary.FilesFailed }} {{ pluralize "file" .Summary.FilesFailed }}.
+{{- end }}
+{{- if gt .Summary.RulesSkipped 0 }} {{ .Summary.RulesSkipped }} {{ pluralize "rule" .Summary.RulesSkipped }} skipped.
+{{- end }}
+</p>
+{{- if .Violations }}
+<h2>Summary</h2>
+<div class="summary">
+<table>
+<tr><th>Category</th><th>Violations</th></tr>
+{{- range .Categories }}
+<tr><td>{{ .Name }}</td><td class="num">{{ .Count }}</td></tr>
+{{- end }}
+</table>
+<table>
+<tr><th>Rule</th><th>Violations</th></tr>
+{{- range .Rules }}
+<tr><td>{{ if .URL }}<a href="{{ .URL }}">{{ .Name }}</a>{{ else }}{{ .Name }}{{ end }}</td><td class="num">{{ .Count }}</td></tr>
+{{- end }}
+</table>
+<table>
+<tr><th>File</th><th>Violations</th></tr>
+{{- range .Files }}
+<tr><td><a href="#{{ .Anchor }}">{{ .Name }}</a></td><td class="num">{{ .Count }}</td></tr>
+{{- end }}
+</table>
+</div>
+<h2>Violations</h2>
+{{- range .Files }}
+<h3 id="{{ .Anchor }}" class="location">{{ .Name }}</h3>
+{{- range .Violations }}
+<div class="violation">
+<h3><span class="level level-{{ .Level }}">{{ .Level }}</span> {{ .Category }}/{{ .Title }}</h3>
+<p>{{ .Description }}</p>
+<p class="location">{{ .Location }}</p>
+{{- if .Snippet }}
+<pre>
+{{- range .Snippet }}<span{{ if .Highlighted }} class="highlighted"{{ end }}><span class="line-number">{{ .Number }}</span>{{ .Text }}</span>{{ end -}}
+</pre>
+{{- end }}
+{{- if .RelatedResources }}
+<ul>
+{{- range .RelatedResources }}
+<li><a href="{{ .Reference }}">{{ .Description }}</a></li>
+{{- end }}
+</ul>
+{{- end }}
+</div>
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .Notices }}
+<h2>Notices</h2>
+<ul>
+{{- range .Notices }}
+<li>{{ .Title }}: {{ .Description }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if .Profile }}
+<h2>Profile</h2>
+<table>
+<tr><th>Location</th><th>Total Time</th><th>Evaluations</th><th>Redos</th><th>Generated Expressions</th></tr>
+{{- range .Profile }}
+<tr><td class="location">{{ .Location }}</td><td class="num">{{ duration .TotalTimeNs }}</td><td class="num">{{ .NumEval }}</td><td class="num">{{ .NumRedo }}</td><td class="num">{{ .NumGenExpr }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if .Metrics }}
+<h2>Metrics</h2>
+<table>
+<tr><th>Metric</th><th>Value</th></tr>
+{{- range .Metrics }}
+<tr><td>{{ .Name }}</td><td class="num">{{ .Value }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
@@ -208,14 +208,14 @@ func FindManifestLocations(root string) ([]string, error) {
 
 func ModulesFromCustomRuleFS(customRuleFS fs.FS, rootPath string) (map[string]*ast.Module, error) {
 	modules, err := files.DefaultWalkReducer(rootPath, make(map[string]*ast.Module)).
-		WithFilters(filter.RegoTests).
+		WithFilters(filter.RegoTests, filter.Suffixes(".schema.json")).
 		ReduceFS(customRuleFS, func(path string, modules map[string]*ast.Module) (map[string]*ast.Module, error) {
 			bs, err := fs.ReadFile(customRuleFS, path)
 			if err != nil {
 				return modules, fmt.Errorf("failed to read custom rule file: %w", err)
 			}
 
-			m, err := ast.ParseModule(path, outil.ByteSliceToString(bs))
+			m, err := ast.ParseModuleWithOpts(path, outil.ByteSliceToString(bs), ast.ParserOptions{ProcessAnnotation: true})
 			if err != nil {
 				return modules, fmt.Errorf("failed to parse custom rule file %q: %w", path, err)
 			}
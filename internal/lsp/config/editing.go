@@ -0,0 +1,296 @@
+package config
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-policy-agent/regal/internal/lsp/types"
+	"github.com/open-policy-agent/regal/internal/lsp/types/completion"
+	"github.com/open-policy-agent/regal/internal/util"
+	rconfig "github.com/open-policy-agent/regal/pkg/config"
+)
+
+const (
+	diagnosticSource = "regal/config"
+	diagnosticCode   = "invalid-config"
+	diagnosticHref   = "https://docs.styra.com/regal#configuration"
+)
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// IsConfigFile reports whether the file is a Regal configuration file, i.e. .regal.yaml or .regal/config.yaml.
+func IsConfigFile(fileURI string) bool {
+	return util.HasAnySuffix(fileURI, ".regal/config.yaml", ".regal.yaml")
+}
+
+// ErrorDiagnostics returns a diagnostic for the error encountered when reading configuration, placed on the
+// line reported in the error, if any.
+func ErrorDiagnostics(contents string, err error) []types.Diagnostic {
+	line := 0
+	if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+		line, _ = strconv.Atoi(match[1])
+		line = max(line-1, 0)
+	}
+
+	lines := strings.Split(contents, "\n")
+
+	length := 0
+	if line < len(lines) {
+		length = len(lines[line])
+	}
+
+	return []types.Diagnostic{diagnostic(err.Error(), types.RangeBetween(line, 0, line, length))}
+}
+
+// Diagnostics returns diagnostics for the problems found when validating configuration, each placed on
+// the setting it concerns. Problems with settings not found in contents, like those from files extended,
+// are placed at the start of the file.
+func Diagnostics(contents string, problems []rconfig.Problem) []types.Diagnostic {
+	var root yaml.Node
+
+	_ = yaml.Unmarshal([]byte(contents), &root)
+
+	diags := make([]types.Diagnostic, 0, len(problems))
+
+	for _, problem := range problems {
+		if r, ok := rangeOf(&root, problem.Path); ok {
+			diags = append(diags, diagnostic(problem.Message, r))
+		} else {
+			diags = append(diags, diagnostic(problem.String(), types.RangeBetween(0, 0, 0, 0)))
+		}
+	}
+
+	return diags
+}
+
+// Completions returns completion items for the keys, or values, that the schema allows at the position
+// in contents. Since contents are likely incomplete while being edited, keys leading to the position are
+// determined from the indentation of the lines above, rather than by parsing the YAML.
+func Completions(schema *rconfig.Schema, contents string, position types.Position) []types.CompletionItem {
+	lines := strings.Split(contents, "\n")
+	if int(position.Line) >= len(lines) {
+		return nil
+	}
+
+	line := lines[position.Line]
+	prefix := line[:min(int(position.Character), len(line))]
+
+	if strings.HasPrefix(strings.TrimSpace(prefix), "#") {
+		return nil
+	}
+
+	current, ok := parseLine(prefix)
+	if !ok {
+		current = yamlLine{indent: len(prefix), dash: -1}
+	}
+
+	path := keyPath(lines, int(position.Line), current)
+
+	wordStart := len(prefix)
+	for wordStart > 0 && isWordChar(prefix[wordStart-1]) {
+		wordStart--
+	}
+
+	replace := types.RangeBetween(position.Line, wordStart, position.Line, len(prefix))
+
+	if current.key != "" {
+		return valueCompletions(schema.Lookup(append(path, current.key)...), replace)
+	}
+
+	s := schema.Lookup(path...)
+	if s == nil {
+		return nil
+	}
+
+	suffix := ":"
+	if strings.Contains(line[len(prefix):], ":") {
+		suffix = ""
+	}
+
+	items := make([]types.CompletionItem, 0, len(s.Properties))
+
+	for _, key := range s.Keys() {
+		property := s.Properties[key]
+
+		item := types.CompletionItem{
+			Label:    key,
+			Kind:     completion.Property,
+			Detail:   property.Type,
+			TextEdit: &types.TextEdit{NewText: key + suffix, Range: replace},
+		}
+
+		if property.Description != "" {
+			item.Documentation = types.Markdown(property.Description)
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// Hover returns the description of the key at the position in contents, as found in the schema,
+// or nil if there is no key at the position, or no description of it.
+func Hover(schema *rconfig.Schema, contents string, position types.Position) *types.Hover {
+	lines := strings.Split(contents, "\n")
+	if int(position.Line) >= len(lines) {
+		return nil
+	}
+
+	current, ok := parseLine(lines[position.Line])
+	if !ok || current.key == "" {
+		return nil
+	}
+
+	start := current.indent
+	if lines[position.Line][start] == '"' || lines[position.Line][start] == '\'' {
+		start++
+	}
+
+	end := start + len(current.key)
+	if int(position.Character) < start || int(position.Character) > end {
+		return nil
+	}
+
+	s := schema.Lookup(append(keyPath(lines, int(position.Line), current), current.key)...)
+	if s == nil || s.Description == "" {
+		return nil
+	}
+
+	return &types.Hover{
+		Contents: *types.Markdown("### " + current.key + "\n\n" + s.Description),
+		Range:    types.RangeBetween(position.Line, start, position.Line, end),
+	}
+}
+
+func valueCompletions(s *rconfig.Schema, replace types.Range) []types.CompletionItem {
+	if s == nil {
+		return nil
+	}
+
+	values := s.Enum
+	if s.Type == "boolean" {
+		values = []string{"true", "false"}
+	}
+
+	items := make([]types.CompletionItem, 0, len(values))
+
+	for _, value := range values {
+		items = append(items, types.CompletionItem{
+			Label:    value,
+			Kind:     completion.EnumMember,
+			Detail:   s.Description,
+			TextEdit: &types.TextEdit{NewText: value, Range: replace},
+		})
+	}
+
+	return items
+}
+
+// yamlLine is a line of YAML, parsed only as far as needed to tell the keys leading to it.
+type yamlLine struct {
+	// key is the key of the line, if any
+	key string
+	// indent is the column of the key, or value, of the line, following any list item dash
+	indent int
+	// dash is the column of the dash of a list item, or -1 if the line is not a list item
+	dash int
+}
+
+func parseLine(text string) (yamlLine, bool) {
+	trimmed := strings.TrimLeft(text, " ")
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return yamlLine{}, false
+	}
+
+	line := yamlLine{indent: len(text) - len(trimmed), dash: -1}
+
+	if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+		rest := strings.TrimLeft(trimmed[1:], " ")
+		line.dash = line.indent
+		line.indent += len(trimmed) - len(rest)
+		trimmed = rest
+	}
+
+	if i := strings.Index(trimmed, ":"); i > 0 && (i == len(trimmed)-1 || trimmed[i+1] == ' ') {
+		line.key = strings.Trim(trimmed[:i], `"'`)
+	}
+
+	return line, true
+}
+
+// keyPath returns the keys leading to the line at index, given its parsed contents, with "-" for
+// each list item along the way.
+func keyPath(lines []string, index int, current yamlLine) []string {
+	threshold := current.indent
+	if current.dash >= 0 {
+		threshold = current.dash
+	}
+
+	var path []string
+
+	if current.dash >= 0 {
+		path = append(path, "-")
+	}
+
+	for i := index - 1; i >= 0 && threshold > 0; i-- {
+		line, ok := parseLine(lines[i])
+		if !ok {
+			continue
+		}
+
+		if line.key != "" && line.indent < threshold {
+			path = append(path, line.key)
+			threshold = line.indent
+		}
+
+		if line.dash >= 0 && line.dash < threshold {
+			path = append(path, "-")
+			threshold = line.dash
+		}
+	}
+
+	slices.Reverse(path)
+
+	return path
+}
+
+// rangeOf returns the range of the setting at the dot-separated path in the document. The range is
+// that of the value for levels, and that of the key for other settings, as problems with those most
+// often concern the key itself.
+func rangeOf(root *yaml.Node, path string) (types.Range, bool) {
+	target, value := rconfig.Locate(root, path)
+	if target == nil {
+		return types.Range{}, false
+	}
+
+	if strings.HasSuffix(path, ".level") {
+		target = value
+	}
+
+	line, column := target.Line-1, target.Column-1
+
+	return types.RangeBetween(line, column, line, column+max(len(target.Value), 1)), true
+}
+
+func diagnostic(message string, r types.Range) types.Diagnostic {
+	source := diagnosticSource
+
+	return types.Diagnostic{
+		Severity:        util.Pointer(uint(1)),
+		Range:           r,
+		Message:         message,
+		Source:          &source,
+		Code:            diagnosticCode,
+		CodeDescription: &types.CodeDescription{Href: diagnosticHref},
+	}
+}
+
+func isWordChar(c byte) bool {
+	return c == '-' || c == '_' || c == '.' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
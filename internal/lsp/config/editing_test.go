@@ -0,0 +1,169 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/open-policy-agent/regal/internal/lsp/types"
+	rconfig "github.com/open-policy-agent/regal/pkg/config"
+)
+
+var testSchema = &rconfig.Schema{
+	Type: "object",
+	Properties: map[string]*rconfig.Schema{
+		"rules": {Type: "object", Properties: map[string]*rconfig.Schema{
+			"style": {Type: "object", Properties: map[string]*rconfig.Schema{
+				"line-length": {
+					Description: "Line too long",
+					Type:        "object",
+					Properties: map[string]*rconfig.Schema{
+						"level":           {Type: "string", Enum: []string{"error", "warning", "ignore"}},
+						"max-line-length": {Description: "Maximum length of lines", Type: "integer"},
+					},
+				},
+				"todo-comment": {Description: "Avoid TODO comments", Type: "object"},
+			}},
+		}},
+		"ignore": {Type: "object", Properties: map[string]*rconfig.Schema{
+			"files": {Type: "array", Items: &rconfig.Schema{Type: "string"}},
+		}},
+		"overrides": {Type: "array", Items: &rconfig.Schema{Type: "object", Properties: map[string]*rconfig.Schema{
+			"files": {Type: "array"},
+			"rules": {Type: "object"},
+		}}},
+	},
+}
+
+func TestCompletions(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		contents string
+		position types.Position
+		expected []string
+	}{
+		"top level keys": {
+			contents: "ig",
+			position: types.Position{Line: 0, Character: 2},
+			expected: []string{"ignore:", "overrides:", "rules:"},
+		},
+		"rule names": {
+			contents: "rules:\n  style:\n    \n",
+			position: types.Position{Line: 2, Character: 4},
+			expected: []string{"line-length:", "todo-comment:"},
+		},
+		"rule attributes": {
+			contents: "rules:\n  style:\n    line-length:\n      max\n",
+			position: types.Position{Line: 3, Character: 9},
+			expected: []string{"level:", "max-line-length:"},
+		},
+		"levels": {
+			contents: "rules:\n  style:\n    line-length:\n      level: \n",
+			position: types.Position{Line: 3, Character: 13},
+			expected: []string{"error", "warning", "ignore"},
+		},
+		"keys of list items": {
+			contents: "overrides:\n  - fi\n",
+			position: types.Position{Line: 1, Character: 6},
+			expected: []string{"files:", "rules:"},
+		},
+		"comment": {
+			contents: "# ru",
+			position: types.Position{Line: 0, Character: 4},
+			expected: []string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			items := Completions(testSchema, tc.contents, tc.position)
+
+			actual := make([]string, 0, len(items))
+			for _, item := range items {
+				actual = append(actual, item.TextEdit.NewText)
+			}
+
+			if !slices.Equal(actual, tc.expected) {
+				t.Errorf("expected completions %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHover(t *testing.T) {
+	t.Parallel()
+
+	contents := "rules:\n  style:\n    line-length:\n      max-line-length: 100\n"
+
+	hover := Hover(testSchema, contents, types.Position{Line: 3, Character: 10})
+	if hover == nil {
+		t.Fatal("expected hover")
+	}
+
+	if !strings.Contains(hover.Contents.Value, "Maximum length of lines") {
+		t.Errorf("expected description in hover, got %q", hover.Contents.Value)
+	}
+
+	if expected := types.RangeBetween(3, 6, 3, 21); hover.Range != expected {
+		t.Errorf("expected range %v, got %v", expected, hover.Range)
+	}
+
+	if hover := Hover(testSchema, contents, types.Position{Line: 3, Character: 24}); hover != nil {
+		t.Errorf("expected no hover for value, got %v", hover)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	contents := "rules:\n  style:\n    line-length:\n      level: warn\n      max-line-lenght: 100\n" +
+		"overrides:\n- files: []\n"
+
+	problems := []rconfig.Problem{
+		{Path: "rules.style.line-length.level", Message: "invalid level warn"},
+		{Path: "rules.style.line-length.max-line-lenght", Message: "unknown attribute"},
+		{Path: "overrides[0].files", Message: "override must list the files it applies to"},
+		{Path: "rules.bugs.extended-rule", Message: "unknown rule"},
+	}
+
+	expected := []types.Range{
+		types.RangeBetween(3, 13, 3, 17),
+		types.RangeBetween(4, 6, 4, 21),
+		types.RangeBetween(6, 2, 6, 7),
+		types.RangeBetween(0, 0, 0, 0),
+	}
+
+	diags := Diagnostics(contents, problems)
+	if len(diags) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %d", len(expected), len(diags))
+	}
+
+	for i, diag := range diags {
+		if diag.Range != expected[i] {
+			t.Errorf("expected range %v for %s, got %v", expected[i], problems[i].Path, diag.Range)
+		}
+	}
+
+	if diags[3].Message != "rules.bugs.extended-rule: unknown rule" {
+		t.Errorf("expected path in message of problem not found, got %q", diags[3].Message)
+	}
+}
+
+func TestErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	contents := "rules:\n  style: [\n"
+
+	diags := ErrorDiagnostics(contents, errors.New("yaml: line 2: did not find expected node content"))
+	if len(diags) != 1 {
+		t.Fatalf("expected one diagnostic, got %d", len(diags))
+	}
+
+	if expected := types.RangeBetween(1, 0, 1, 10); diags[0].Range != expected {
+		t.Errorf("expected range %v, got %v", expected, diags[0].Range)
+	}
+}
@@ -317,6 +317,18 @@ func convertReportToDiagnostics(rpt *report.Report, workspaceRootURI string) map
 		file := cmp.Or(item.Location.File, workspaceRootURI)
 		source := "regal/" + item.Category
 
+		var relatedInformation []types.DiagnosticRelatedInformation
+
+		for _, related := range item.RelatedLocations {
+			relatedInformation = append(relatedInformation, types.DiagnosticRelatedInformation{
+				Location: types.Location{
+					URI:   cmp.Or(related.Location.File, file),
+					Range: getRangeForLocation(related.Location),
+				},
+				Message: related.Message,
+			})
+		}
+
 		fileDiags[file] = append(fileDiags[file], types.Diagnostic{
 			Severity: &severity,
 			Range:    getRangeForViolation(item),
@@ -330,6 +342,7 @@ func convertReportToDiagnostics(rpt *report.Report, workspaceRootURI string) map
 					item.Title,
 				),
 			},
+			RelatedInformation: relatedInformation,
 		})
 	}
 
@@ -337,18 +350,22 @@ func convertReportToDiagnostics(rpt *report.Report, workspaceRootURI string) map
 }
 
 func getRangeForViolation(item report.Violation) types.Range {
-	startLine, startChar := max(item.Location.Row-1, 0), max(item.Location.Column-1, 0)
+	return getRangeForLocation(item.Location)
+}
+
+func getRangeForLocation(location report.Location) types.Range {
+	startLine, startChar := max(location.Row-1, 0), max(location.Column-1, 0)
 
-	if item.Location.End != nil {
+	if location.End != nil {
 		return types.RangeBetween(
 			startLine, startChar,
-			max(item.Location.End.Row-1, 0), max(item.Location.End.Column-1, 0),
+			max(location.End.Row-1, 0), max(location.End.Column-1, 0),
 		)
 	}
 
 	itemLen := 0
-	if item.Location.Text != nil {
-		itemLen = len(*item.Location.Text)
+	if location.Text != nil {
+		itemLen = len(*location.Text)
 	}
 
 	return types.RangeBetween(startLine, startChar, startLine, startChar+itemLen)
@@ -158,6 +158,10 @@ func TestConvertReportToDiagnostics(t *testing.T) {
 		Category:    "mock_category",
 		Title:       "mock_title",
 		Location:    report.Location{File: "file1"},
+		RelatedLocations: []report.RelatedLocation{{
+			Message:  "Mock related location",
+			Location: report.Location{File: "file1", Row: 3, Column: 2, End: &report.Position{Row: 3, Column: 5}},
+		}},
 		IsAggregate: false,
 	}
 	violation2 := report.Violation{
@@ -184,6 +188,10 @@ func TestConvertReportToDiagnostics(t *testing.T) {
 				CodeDescription: &types.CodeDescription{
 					Href: "https://docs.styra.com/regal/rules/mock_category/mock_title",
 				},
+				RelatedInformation: []types.DiagnosticRelatedInformation{{
+					Location: types.Location{URI: "file1", Range: types.RangeBetween(2, 1, 2, 4)},
+					Message:  "Mock related location",
+				}},
 			},
 		},
 		"workspaceRootURI": {
@@ -116,6 +116,9 @@ type LanguageServer struct {
 	loadedConfigEnabledAggregateRules    []string
 	loadedConfigAllRegoVersions          *concurrent.Map[string, ast.RegoVersion]
 	loadedBuiltins                       *concurrent.Map[string, map[string]*ast.Builtin]
+	// nestedConfigOverrides caches the overrides of nested config files, keyed by project root and
+	// ignore patterns, as finding these means walking the workspace. Cleared when config files change.
+	nestedConfigOverrides *concurrent.Map[string, []config.Override]
 
 	client types.Client
 
@@ -129,6 +132,7 @@ type LanguageServer struct {
 	lintFileJobs         chan lintFileJob
 	builtinsPositionJobs chan lintFileJob
 	templateFileJobs     chan lintFileJob
+	configFileJobs       chan lintFileJob
 
 	// templatingFiles tracks files currently being templated to ensure
 	// other updates are not processed while the file is being updated.
@@ -170,10 +174,12 @@ func NewLanguageServer(ctx context.Context, opts *LanguageServerOptions) *Langua
 		builtinsPositionJobs:        make(chan lintFileJob, 10),
 		commandRequest:              make(chan types.ExecuteCommandParams, 10),
 		templateFileJobs:            make(chan lintFileJob, 10),
+		configFileJobs:              make(chan lintFileJob, 10),
 		templatingFiles:             concurrent.MapOf(make(map[string]bool)),
 		completionsManager:          completions.NewDefaultManager(ctx, c, store),
 		webServer:                   web.NewServer(c, opts.Logger),
 		loadedBuiltins:              concurrent.MapOf(make(map[string]map[string]*ast.Builtin)),
+		nestedConfigOverrides:       concurrent.MapOf(make(map[string][]config.Override)),
 		workspaceDiagnosticsPoll:    opts.WorkspaceDiagnosticsPoll,
 		loadedConfigAllRegoVersions: concurrent.MapOf(make(map[string]ast.RegoVersion)),
 	}
@@ -199,10 +205,12 @@ func NewLanguageServerMinimal(ctx context.Context, opts *LanguageServerOptions,
 		builtinsPositionJobs:        make(chan lintFileJob, 10),
 		commandRequest:              make(chan types.ExecuteCommandParams, 10),
 		templateFileJobs:            make(chan lintFileJob, 10),
+		configFileJobs:              make(chan lintFileJob, 10),
 		templatingFiles:             concurrent.MapOf(make(map[string]bool)),
 		completionsManager:          completions.NewDefaultManager(ctx, c, store),
 		webServer:                   web.NewServer(c, opts.Logger),
 		loadedBuiltins:              concurrent.MapOf(make(map[string]map[string]*ast.Builtin)),
+		nestedConfigOverrides:       concurrent.MapOf(make(map[string][]config.Override)),
 		workspaceDiagnosticsPoll:    opts.WorkspaceDiagnosticsPoll,
 		loadedConfigAllRegoVersions: concurrent.MapOf(make(map[string]ast.RegoVersion)),
 	}
@@ -365,6 +373,19 @@ func (l *LanguageServer) StartDiagnosticsWorker(ctx context.Context) {
 		}
 	})
 
+	wg.Go(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case job := <-l.configFileJobs:
+				if err := l.sendConfigFileDiagnostics(ctx, job.URI); err != nil {
+					l.log.Message("failed to send config file diagnostics: %s", err)
+				}
+			}
+		}
+	})
+
 	wg.Add(1)
 
 	workspaceLintRunBufferSize := 10
@@ -494,6 +515,12 @@ func (l *LanguageServer) StartConfigWorker(ctx context.Context) {
 				continue
 			}
 
+			if err := l.addNestedConfigOverrides(&userConfig, path); err != nil {
+				l.log.Message("failed to reload config: %s", err)
+
+				continue
+			}
+
 			mergedConfig, err := config.LoadConfigWithDefaultsFromBundle(rbundle.LoadedBundle(), &userConfig)
 			if err != nil {
 				l.log.Message("failed to load config: %s", err)
@@ -527,7 +554,10 @@ func (l *LanguageServer) StartConfigWorker(ctx context.Context) {
 			}
 
 			// Capabilities URL may have changed, so we should reload it.
-			capsURL := cmp.Or(mergedConfig.CapabilitiesURL, capabilities.DefaultURL)
+			capsURL := capabilitiesURL(&mergedConfig)
+			if capabilities.IsExecURL(mergedConfig.CapabilitiesURL) {
+				l.log.Message("not running OPA binary to load capabilities from %q, using default", mergedConfig.CapabilitiesURL)
+			}
 
 			caps, err := capabilities.Lookup(ctx, capsURL)
 			if err != nil {
@@ -680,6 +710,12 @@ func (l *LanguageServer) StartCommandWorker(ctx context.Context) { //nolint:main
 					&fixes.NonRawRegexPattern{},
 					args,
 				)
+			case "regal.fix.unused-ignore-directive":
+				fixed, editParams, err = l.fixEditParams(
+					"Remove unused ignore directive",
+					&fixes.UnusedIgnoreDirective{},
+					args,
+				)
 			case "regal.fix.directory-package-mismatch":
 				params, err := l.fixRenameParams(
 					"Rename file to match package path",
@@ -1330,6 +1366,10 @@ func (l *LanguageServer) processHoverContentUpdate(ctx context.Context, fileURI
 }
 
 func (l *LanguageServer) handleTextDocumentHover(params types.TextDocumentHoverParams) (any, error) {
+	if lsconfig.IsConfigFile(params.TextDocument.URI) {
+		return l.configFileHover(params)
+	}
+
 	if l.ignoreURI(params.TextDocument.URI) {
 		return nil, nil
 	}
@@ -1564,6 +1604,10 @@ func (l *LanguageServer) handleTextDocumentCodeLens(ctx context.Context, params
 }
 
 func (l *LanguageServer) handleTextDocumentCompletion(ctx context.Context, params types.CompletionParams) (any, error) {
+	if lsconfig.IsConfigFile(params.TextDocument.URI) {
+		return l.configFileCompletions(params)
+	}
+
 	// when config ignores a file, then we return an empty completion list as a no-op.
 	if l.ignoreURI(params.TextDocument.URI) {
 		return types.CompletionList{IsIncomplete: false, Items: []types.CompletionItem{}}, nil
@@ -1699,6 +1743,10 @@ func (l *LanguageServer) handleTextDocumentDidOpen(params types.DidOpenTextDocum
 	if l.ignoreURI(params.TextDocument.URI) {
 		l.cache.SetIgnoredFileContents(params.TextDocument.URI, params.TextDocument.Text)
 
+		if lsconfig.IsConfigFile(params.TextDocument.URI) {
+			l.configFileJobs <- lintFileJob{Reason: "textDocument/didOpen", URI: params.TextDocument.URI}
+		}
+
 		return struct{}{}, nil
 	}
 
@@ -1758,6 +1806,8 @@ func (l *LanguageServer) handleTextDocumentDidChange(params types.DidChangeTextD
 		l.lintFileJobs <- job
 
 		l.builtinsPositionJobs <- job
+	} else if lsconfig.IsConfigFile(params.TextDocument.URI) {
+		l.configFileJobs <- lintFileJob{Reason: "textDocument/didChange", URI: params.TextDocument.URI}
 	}
 
 	return struct{}{}, nil
@@ -2171,6 +2221,7 @@ func (l *LanguageServer) handleInitialize(ctx context.Context, params types.Init
 					"regal.fix.no-whitespace-comment",
 					"regal.fix.directory-package-mismatch",
 					"regal.fix.non-raw-regex-pattern",
+					"regal.fix.unused-ignore-directive",
 				},
 			},
 			DocumentFormattingProvider: true,
@@ -2331,8 +2382,11 @@ func (l *LanguageServer) handleWorkspaceDidChangeWatchedFiles(
 	regoFiles := make([]string, 0, len(params.Changes))
 
 	for _, change := range params.Changes {
-		// this handles the case of a new config file being created when one did not exist before
+		// this handles the case of a new config file being created when one did not exist before,
+		// as well as that of nested config files changing, which requires finding these again
 		if util.HasAnySuffix(change.URI, ".regal/config.yaml", ".regal.yaml") {
+			l.nestedConfigOverrides.Clear()
+
 			if configFile, err := config.FindConfig(l.workspacePath()); err == nil {
 				l.configWatcher.Watch(configFile.Name())
 				configFile.Close()
@@ -2379,6 +2433,92 @@ func (l *LanguageServer) sendFileDiagnostics(ctx context.Context, fileURI string
 	return nil
 }
 
+// sendConfigFileDiagnostics validates the contents of a Regal configuration file open in the editor,
+// and sends any problems found as diagnostics of the file.
+func (l *LanguageServer) sendConfigFileDiagnostics(ctx context.Context, fileURI string) error {
+	contents, ok := l.cache.GetIgnoredFileContents(fileURI)
+	if !ok {
+		return nil
+	}
+
+	diags := noDiagnostics
+
+	conf, err := config.FromBytes(l.toPath(fileURI), []byte(contents))
+
+	switch {
+	case errors.Is(err, io.EOF):
+	case err != nil:
+		diags = lsconfig.ErrorDiagnostics(contents, err)
+	default:
+		problems, err := l.configLinter().WithUserConfig(conf).ValidateConfig()
+		if err != nil {
+			return fmt.Errorf("failed to validate config: %w", err)
+		}
+
+		if len(problems) > 0 {
+			diags = lsconfig.Diagnostics(contents, problems)
+		}
+	}
+
+	resp := types.FileDiagnostics{URI: fileURI, Items: diags}
+
+	if err := l.conn.Notify(ctx, methodTdPublishDiagnostics, resp); err != nil {
+		return fmt.Errorf("failed to notify: %w", err)
+	}
+
+	return nil
+}
+
+func (l *LanguageServer) configFileCompletions(params types.CompletionParams) (any, error) {
+	items := []types.CompletionItem{}
+
+	contents, ok := l.cache.GetIgnoredFileContents(params.TextDocument.URI)
+	if !ok {
+		return types.CompletionList{IsIncomplete: false, Items: items}, nil
+	}
+
+	schema, err := l.configLinter().ConfigSchema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config schema: %w", err)
+	}
+
+	if found := lsconfig.Completions(schema, contents, params.Position); found != nil {
+		items = found
+	}
+
+	return types.CompletionList{IsIncomplete: false, Items: items}, nil
+}
+
+func (l *LanguageServer) configFileHover(params types.TextDocumentHoverParams) (any, error) {
+	contents, ok := l.cache.GetIgnoredFileContents(params.TextDocument.URI)
+	if !ok {
+		return nil, nil
+	}
+
+	schema, err := l.configLinter().ConfigSchema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config schema: %w", err)
+	}
+
+	if hover := lsconfig.Hover(schema, contents, params.Position); hover != nil {
+		return hover, nil
+	}
+
+	return nil, nil
+}
+
+// configLinter returns a linter aware of any custom rules of the workspace, for validating
+// configuration and describing it.
+func (l *LanguageServer) configLinter() linter.Linter {
+	lint := linter.NewLinter()
+
+	if customRulesPath := l.getCustomRulesPath(); customRulesPath != "" {
+		lint = lint.WithCustomRules([]string{customRulesPath})
+	}
+
+	return lint
+}
+
 func (l *LanguageServer) getFilteredModules() (map[string]*ast.Module, error) {
 	allModules := l.cache.GetAllModules()
 	ignore := l.getLoadedConfig().Ignore.Files
@@ -2440,7 +2580,7 @@ func (l *LanguageServer) regoVersionForURI(fileURI string) ast.RegoVersion {
 // in the server based on the currently loaded capabilities. If there is no
 // config, then the default for the Regal OPA version is used.
 func (l *LanguageServer) builtinsForCurrentCapabilities() map[string]*ast.Builtin {
-	capsURL := cmp.Or(l.getLoadedConfig().CapabilitiesURL, capabilities.DefaultURL)
+	capsURL := capabilitiesURL(l.getLoadedConfig())
 	if bis, ok := l.loadedBuiltins.Get(capsURL); ok {
 		return bis
 	}
@@ -2448,6 +2588,43 @@ func (l *LanguageServer) builtinsForCurrentCapabilities() map[string]*ast.Builti
 	return rego.BuiltinsForCapabilities(ast.CapabilitiesForThisVersion())
 }
 
+// addNestedConfigOverrides adds the overrides of nested config files to conf like
+// config.AddNestedConfigOverrides, but only walks the workspace to find these when
+// not already cached for the same project root and ignore patterns.
+func (l *LanguageServer) addNestedConfigOverrides(conf *config.Config, configPath string) error {
+	root := config.NestedConfigRoot(configPath)
+	if root == "" {
+		return nil
+	}
+
+	key := strings.Join(append([]string{root}, conf.Ignore.Files...), "\n")
+
+	overrides, ok := l.nestedConfigOverrides.Get(key)
+	if !ok {
+		var err error
+		if overrides, err = config.NestedConfigOverrides(root, conf.Ignore.Files); err != nil {
+			return fmt.Errorf("failed to load nested config files: %w", err)
+		}
+
+		l.nestedConfigOverrides.Set(key, overrides)
+	}
+
+	conf.Overrides = append(conf.Overrides, overrides...)
+
+	return nil
+}
+
+// capabilitiesURL returns the URL to load capabilities from for the config provided. As the config is
+// that of whatever workspace the client opens, an OPA binary named in it is never run, but the default
+// capabilities are used instead.
+func capabilitiesURL(conf *config.Config) string {
+	if conf == nil || conf.CapabilitiesURL == "" || capabilities.IsExecURL(conf.CapabilitiesURL) {
+		return capabilities.DefaultURL
+	}
+
+	return conf.CapabilitiesURL
+}
+
 func (l *LanguageServer) parseOpts(fileURI string, bis map[string]*ast.Builtin) updateParseOpts {
 	return updateParseOpts{
 		Cache:            l.cache,
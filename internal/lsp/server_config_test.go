@@ -4,6 +4,7 @@ import (
 	"context"
 	"path/filepath"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -100,6 +101,53 @@ allow := true
 	}
 }
 
+// TestLanguageServerNestedConfig tests that the rule configuration of .regal.yaml files found in
+// directories below the project root applies to the files in those directories, as for the CLI.
+func TestLanguageServerNestedConfig(t *testing.T) {
+	t.Parallel()
+
+	childDirName := "child"
+
+	files := map[string]string{
+		childDirName + mainRegoFileName: `package child
+
+allow if {
+	input.x
+}
+`,
+		".regal/config.yaml": "rules: {}\n",
+		childDirName + "/.regal.yaml": `rules:
+  custom:
+    one-liner-rule:
+      level: error
+`,
+	}
+
+	tempDir := testutil.TempDirectoryOf(t, files)
+	mainRegoFileURI := fileURIScheme + filepath.Join(tempDir, childDirName) + mainRegoFileName
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	receivedMessages := make(chan types.FileDiagnostics, defaultBufferedChannelSize)
+	clientHandler := test.HandlerFor(methodTdPublishDiagnostics, test.SendsToChannel(receivedMessages))
+
+	createAndInitServer(t, ctx, tempDir, clientHandler)
+
+	timeout := time.NewTimer(determineTimeout())
+	defer timeout.Stop()
+
+	// one-liner-rule is disabled by default, and only enabled by the nested config
+	for success := false; !success; {
+		select {
+		case requestData := <-receivedMessages:
+			success = testRequestDataCodes(t, requestData, mainRegoFileURI, []string{"one-liner-rule"})
+		case <-timeout.C:
+			t.Fatalf("timed out waiting for file diagnostics to be sent")
+		}
+	}
+}
+
 func TestLanguageServerCachesEnabledRulesAndUsesDefaultConfig(t *testing.T) {
 	t.Parallel()
 
@@ -238,3 +286,79 @@ rules:
 		}
 	}
 }
+
+func TestLanguageServerConfigFileEditing(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	configURI := fileURIScheme + filepath.Join(tempDir, ".regal", "config.yaml")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	receivedMessages := make(chan types.FileDiagnostics, defaultBufferedChannelSize)
+	clientHandler := test.HandlerFor(methodTdPublishDiagnostics, test.SendsToChannel(receivedMessages))
+
+	_, connClient := createAndInitServer(t, ctx, tempDir, clientHandler)
+
+	configContents := `rules:
+  style:
+    line-length:
+      level: warn
+      max-line-length: 100
+`
+
+	if err := connClient.Notify(ctx, "textDocument/didOpen", types.DidOpenTextDocumentParams{
+		TextDocument: types.TextDocumentItem{URI: configURI, Text: configContents},
+	}); err != nil {
+		t.Fatalf("failed to send didOpen notification: %s", err)
+	}
+
+	timeout := time.NewTimer(determineTimeout())
+	defer timeout.Stop()
+
+	for success := false; !success; {
+		select {
+		case requestData := <-receivedMessages:
+			if !testRequestDataCodes(t, requestData, configURI, []string{"invalid-config"}) {
+				continue
+			}
+
+			if exp := types.RangeBetween(3, 13, 3, 17); requestData.Items[0].Range != exp {
+				t.Fatalf("expected diagnostic at %v, got %v", exp, requestData.Items[0].Range)
+			}
+
+			success = true
+		case <-timeout.C:
+			t.Fatalf("timed out waiting for config file diagnostics to be sent")
+		}
+	}
+
+	var completions types.CompletionList
+	if err := connClient.Call(
+		ctx, "textDocument/completion", types.NewCompletionParams(configURI, 3, 13, nil), &completions,
+	); err != nil {
+		t.Fatalf("failed to send completion request: %s", err)
+	}
+
+	labels := make([]string, 0, len(completions.Items))
+	for _, item := range completions.Items {
+		labels = append(labels, item.Label)
+	}
+
+	if exp := []string{"error", "warning", "ignore"}; !slices.Equal(labels, exp) {
+		t.Errorf("expected completions %v, got %v", exp, labels)
+	}
+
+	var hover types.Hover
+	if err := connClient.Call(ctx, "textDocument/hover", types.TextDocumentHoverParams{
+		TextDocument: types.TextDocumentIdentifier{URI: configURI},
+		Position:     types.Position{Line: 2, Character: 6},
+	}, &hover); err != nil {
+		t.Fatalf("failed to send hover request: %s", err)
+	}
+
+	if !strings.Contains(hover.Contents.Value, "https://docs.styra.com/regal/rules/style/line-length") {
+		t.Errorf("expected hover to link to rule documentation, got %q", hover.Contents.Value)
+	}
+}
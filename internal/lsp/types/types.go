@@ -446,12 +446,18 @@ type (
 	}
 
 	Diagnostic struct {
-		CodeDescription *CodeDescription `json:"codeDescription,omitempty"`
-		Message         string           `json:"message"`
-		Source          *string          `json:"source,omitempty"`
-		Code            string           `json:"code"` // spec says optional integer or string
-		Range           Range            `json:"range"`
-		Severity        *uint            `json:"severity,omitempty"`
+		CodeDescription    *CodeDescription               `json:"codeDescription,omitempty"`
+		Message            string                         `json:"message"`
+		Source             *string                        `json:"source,omitempty"`
+		Code               string                         `json:"code"` // spec says optional integer or string
+		RelatedInformation []DiagnosticRelatedInformation `json:"relatedInformation,omitempty"`
+		Range              Range                          `json:"range"`
+		Severity           *uint                          `json:"severity,omitempty"`
+	}
+
+	DiagnosticRelatedInformation struct {
+		Location Location `json:"location"`
+		Message  string   `json:"message"`
 	}
 
 	CodeDescription struct {
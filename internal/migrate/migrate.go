@@ -0,0 +1,305 @@
+// Package migrate rewrites Rego v0 policy, and the files describing it, to Rego v1.
+package migrate
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/format"
+
+	"github.com/open-policy-agent/regal/internal/parse"
+	"github.com/open-policy-agent/regal/pkg/config"
+)
+
+// Finding is a pattern found in a Rego v0 module that is no longer allowed, or that changes meaning,
+// in Rego v1, and which therefore needs to be reviewed manually.
+type Finding struct {
+	File    string `json:"file"`
+	Row     int    `json:"row"`
+	Column  int    `json:"col"`
+	Message string `json:"message"`
+}
+
+// Result is the result of migrating a single module.
+type Result struct {
+	File string `json:"file"`
+	// Contents are the contents of the migrated module, or empty if the module was not changed.
+	Contents string `json:"-"`
+	// Compatible are the contents of the migrated module in a form compatible with both Rego v0 and v1,
+	// importing rego.v1, for modules that must remain loadable as Rego v0. Empty if the module was not changed.
+	Compatible string `json:"-"`
+	// Changed is true if the module was rewritten.
+	Changed bool `json:"changed"`
+	// Failed is true if the module could not be rewritten as valid Rego v1, and was left unchanged.
+	Failed bool `json:"failed,omitempty"`
+	// Findings are the patterns of the module that need manual review.
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", f.File, f.Row, f.Column, f.Message)
+}
+
+// regoV1Checks are the checks of Rego v1 not concerning syntax, which is rewritten by the formatter.
+var regoV1Checks = ast.RegoCheckOptions{
+	NoDuplicateImports:      true,
+	NoRootDocumentOverrides: true,
+	NoDeprecatedBuiltins:    true,
+	NoKeywordsAsRuleNames:   true,
+}
+
+// Module migrates a Rego v0 module to Rego v1, using the if and contains keywords where required, and
+// removing imports of future.keywords and rego.v1. Modules already in Rego v1 are left unchanged. Patterns
+// that need manual review, like calls to deprecated built-in functions or keywords used as variable names,
+// are reported as findings. Modules that can't be rewritten as valid Rego v1 are left unchanged, and marked
+// as failed.
+func Module(filename, contents string) (Result, error) {
+	result := Result{File: filename}
+
+	opts := parse.ParserOptions()
+	opts.RegoVersion = ast.RegoV0
+
+	module, err := ast.ParseModuleWithOpts(filename, contents, opts)
+	if err != nil {
+		opts.RegoVersion = ast.RegoV1
+		if _, v1err := ast.ParseModuleWithOpts(filename, contents, opts); v1err == nil {
+			return result, nil
+		}
+
+		return result, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	result.Findings = findings(filename, module)
+
+	// formatted for compatibility before the imports are removed, as the formatter updates these itself
+	compatible, err := format.AstWithOpts(module.Copy(), format.Opts{
+		RegoVersion:   ast.RegoV0CompatV1,
+		ParserOptions: &ast.ParserOptions{RegoVersion: ast.RegoV0},
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to format %s: %w", filename, err)
+	}
+
+	module.Imports = slices.DeleteFunc(module.Imports, func(imp *ast.Import) bool {
+		path := imp.Path.Value.(ast.Ref) //nolint:forcetypeassert
+
+		return path.Equal(ast.RegoV1CompatibleRef) || path.HasPrefix(ast.MustParseRef("future.keywords"))
+	})
+
+	formatted, err := format.AstWithOpts(module, format.Opts{
+		RegoVersion:   ast.RegoV1,
+		ParserOptions: &ast.ParserOptions{RegoVersion: ast.RegoV0},
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to format %s: %w", filename, err)
+	}
+
+	opts.RegoVersion = ast.RegoV1
+	if _, err := ast.ParseModuleWithOpts(filename, string(formatted), opts); err != nil {
+		result.Failed = true
+		result.Findings = append(result.Findings, Finding{
+			File:    filename,
+			Row:     1,
+			Column:  1,
+			Message: "module left unchanged, as it could not be rewritten as valid Rego v1: " + err.Error(),
+		})
+		result.Findings = sorted(result.Findings)
+
+		return result, nil
+	}
+
+	if string(formatted) != contents {
+		result.Contents = string(formatted)
+		result.Compatible = string(compatible)
+		result.Changed = true
+	}
+
+	result.Findings = sorted(result.Findings)
+
+	return result, nil
+}
+
+func findings(filename string, module *ast.Module) []Finding {
+	var result []Finding
+
+	for _, err := range ast.CheckRegoV1WithOptions(module, regoV1Checks) {
+		result = append(result, finding(filename, err.Location, err.Message))
+	}
+
+	// keywords of Rego v1 may be used as variable names in Rego v0 modules not importing them
+	ast.WalkTerms(module, func(term *ast.Term) bool {
+		if v, ok := term.Value.(ast.Var); ok && ast.IsFutureKeywordForRegoVersion(string(v), ast.RegoV0) {
+			result = append(result, finding(filename, term.Location, fmt.Sprintf("%s is a keyword in Rego v1", v)))
+		}
+
+		return false
+	})
+
+	return result
+}
+
+func sorted(findings []Finding) []Finding {
+	slices.SortFunc(findings, func(a, b Finding) int {
+		return cmp.Or(cmp.Compare(a.Row, b.Row), cmp.Compare(a.Column, b.Column), strings.Compare(a.Message, b.Message))
+	})
+
+	return slices.Compact(findings)
+}
+
+func finding(filename string, location *ast.Location, message string) Finding {
+	f := Finding{File: filename, Row: 1, Column: 1, Message: message}
+	if location != nil {
+		f.Row, f.Column = location.Row, location.Col
+	}
+
+	return f
+}
+
+// Manifest returns the contents of the .manifest file of a bundle updated to declare Rego v1, replacing
+// a rego_version of 0, and any Rego version of 0 in file_rego_versions, with 1. Other contents of the
+// file are left as is. Nil is returned if nothing needs to be updated, or if any of the failed modules
+// provided are found in the bundle, as these must remain loadable as Rego v0.
+func Manifest(path string, failed []string) ([]byte, error) {
+	if containsAny(filepath.Dir(path), failed) {
+		return nil, nil
+	}
+
+	bs, doc, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*yaml.Node
+
+	if _, value := config.Locate(doc, "rego_version"); value != nil {
+		nodes = append(nodes, value)
+	}
+
+	if _, versions := config.Locate(doc, "file_rego_versions"); versions != nil && versions.Kind == yaml.MappingNode {
+		for i := 1; i < len(versions.Content); i += 2 {
+			nodes = append(nodes, versions.Content[i])
+		}
+	}
+
+	return replaceVersions(bs, nodes), nil
+}
+
+// Config returns the contents of the Regal configuration file at path updated to declare Rego v1, replacing
+// a project.rego-version of 0 with 1, when the directory of the project is one of the migrated paths provided,
+// or found below any of them. Likewise, a rego-version of 0 of any project root is replaced with 1 when the
+// root is one of the migrated paths, or found below any of them. Versions are not updated for the project, or
+// roots, where any of the failed modules provided are found, as these must remain loadable as Rego v0. Nil is
+// returned if nothing needs to be updated.
+func Config(path string, migrated, failed []string) ([]byte, error) {
+	bs, doc, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	projectDir := filepath.Dir(path)
+	if filepath.Base(projectDir) == ".regal" {
+		projectDir = filepath.Dir(projectDir)
+	}
+
+	var nodes []*yaml.Node
+
+	if _, value := config.Locate(doc, "project.rego-version"); value != nil && covered(projectDir, migrated) &&
+		!containsAny(projectDir, failed) {
+		nodes = append(nodes, value)
+	}
+
+	if _, roots := config.Locate(doc, "project.roots"); roots != nil && roots.Kind == yaml.SequenceNode {
+		for i := range roots.Content {
+			_, root := config.Locate(doc, fmt.Sprintf("project.roots[%d].path", i))
+			_, version := config.Locate(doc, fmt.Sprintf("project.roots[%d].rego-version", i))
+
+			if root == nil || version == nil {
+				continue
+			}
+
+			if rootDir := filepath.Join(projectDir, root.Value); covered(rootDir, migrated) && !containsAny(rootDir, failed) {
+				nodes = append(nodes, version)
+			}
+		}
+	}
+
+	return replaceVersions(bs, nodes), nil
+}
+
+// read reads the YAML, or JSON, file at path, returning both its contents and the parsed document,
+// which provides the location of each value.
+func read(path string) ([]byte, *yaml.Node, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bs, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return bs, &doc, nil
+}
+
+// replaceVersions replaces the values of the nodes that are 0 with 1, in place, leaving the rest of the
+// contents untouched. Nil is returned if no values were replaced.
+func replaceVersions(bs []byte, nodes []*yaml.Node) []byte {
+	lines := bytes.SplitAfter(bs, []byte("\n"))
+	changed := false
+
+	for _, node := range nodes {
+		if node.Kind != yaml.ScalarNode || node.Value != "0" || node.Line > len(lines) {
+			continue
+		}
+
+		line, column := lines[node.Line-1], node.Column-1
+		if column < len(line) && line[column] == '0' {
+			line[column] = '1'
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return bytes.Join(lines, nil)
+}
+
+// covered reports whether dir is one of the paths provided, or found below any of them.
+func covered(dir string, paths []string) bool {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+
+		if rel, err := filepath.Rel(abs, dir); err == nil && rel != ".." &&
+			!strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsAny reports whether any of the files provided is found below dir.
+func containsAny(dir string, files []string) bool {
+	return slices.ContainsFunc(files, func(file string) bool {
+		return covered(file, []string{dir})
+	})
+}
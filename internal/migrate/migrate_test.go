@@ -0,0 +1,265 @@
+package migrate
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestModule(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		note     string
+		contents string
+		expected string
+		findings []string
+	}{
+		{
+			note: "rewrites rules and removes imports",
+			contents: `package p
+
+import future.keywords.in
+import future.keywords.if
+
+deny[msg] {
+	"a" in input.xs
+	msg := "a"
+}
+
+obj[k] = v {
+	some k, v in input
+}
+`,
+			expected: `package p
+
+deny contains msg if {
+	"a" in input.xs
+	msg := "a"
+}
+
+obj[k] := v if {
+	some k, v in input
+}
+`,
+		},
+		{
+			note:     "removes rego.v1 import",
+			contents: "package p\n\nimport rego.v1\n\nallow if input.x\n",
+			expected: "package p\n\nallow if input.x\n",
+		},
+		{
+			note:     "reports patterns for review",
+			contents: "package p\n\nallow {\n\tany([input.x])\n\tinput := 1\n\tcontains := input\n}\n",
+			expected: "package p\n\nallow if {\n\tany([input.x])\n\tinput := 1\n\tcontains := input\n}\n",
+			findings: []string{
+				"p.rego:4:2: deprecated built-in function calls in expression: any",
+				"p.rego:5:2: variables must not shadow input (use a different variable name)",
+				"p.rego:6:2: contains is a keyword in Rego v1",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.note, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := Module("p.rego", tc.contents)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !result.Changed || result.Contents != tc.expected {
+				t.Errorf("expected contents:\n%s\ngot:\n%s", tc.expected, result.Contents)
+			}
+
+			findings := make([]string, 0, len(result.Findings))
+			for _, f := range result.Findings {
+				findings = append(findings, f.String())
+			}
+
+			if !slices.Equal(findings, tc.findings) {
+				t.Errorf("expected findings %v, got %v", tc.findings, findings)
+			}
+		})
+	}
+}
+
+func TestModuleAlreadyRegoV1(t *testing.T) {
+	t.Parallel()
+
+	result, err := Module("p.rego", "package p\n\nallow if input.x\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Changed || len(result.Findings) > 0 {
+		t.Errorf("expected module to be left unchanged, got %+v", result)
+	}
+}
+
+func TestModuleFailed(t *testing.T) {
+	t.Parallel()
+
+	result, err := Module("p.rego", "package p\n\ncontains := 1\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Failed || result.Changed || result.Contents != "" || result.Compatible != "" {
+		t.Errorf("expected module to be left unchanged and failed, got %+v", result)
+	}
+
+	for i := 1; i < len(result.Findings); i++ {
+		if a, b := result.Findings[i-1], result.Findings[i]; a.Row > b.Row || (a.Row == b.Row && a.Column > b.Column) {
+			t.Errorf("expected findings to be sorted, got %v", result.Findings)
+		}
+	}
+}
+
+func TestModuleCompatible(t *testing.T) {
+	t.Parallel()
+
+	result, err := Module("p.rego", "package p\n\nimport future.keywords.if\n\nallow if input.x\n\ndeny[x] {\n\tx := 1\n}\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "package p\n\nimport rego.v1\n\nallow if input.x\n\ndeny contains x if {\n\tx := 1\n}\n"
+	if result.Compatible != expected {
+		t.Errorf("expected compatible contents:\n%s\ngot:\n%s", expected, result.Compatible)
+	}
+}
+
+func TestManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := testutil.TempDirectoryOf(t, map[string]string{
+		".manifest": "{\n  \"revision\": \"0\",\n  \"rego_version\": 0,\n  \"file_rego_versions\": {\"x/*.rego\": 0}\n}\n",
+	})
+
+	contents, err := Manifest(filepath.Join(dir, ".manifest"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "{\n  \"revision\": \"0\",\n  \"rego_version\": 1,\n  \"file_rego_versions\": {\"x/*.rego\": 1}\n}\n"
+	if string(contents) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, contents)
+	}
+}
+
+func TestManifestWithFailedModule(t *testing.T) {
+	t.Parallel()
+
+	dir := testutil.TempDirectoryOf(t, map[string]string{
+		"b/.manifest": "{\"rego_version\": 0}\n",
+		"b/c.rego":    "package c\n\ncontains := 1\n",
+		"d/.manifest": "{\"rego_version\": 0}\n",
+	})
+	failed := []string{filepath.Join(dir, "b", "c.rego")}
+
+	contents, err := Manifest(filepath.Join(dir, "b", ".manifest"), failed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if contents != nil {
+		t.Errorf("expected manifest of bundle with failed module to be left unchanged, got:\n%s", contents)
+	}
+
+	contents, err = Manifest(filepath.Join(dir, "d", ".manifest"), failed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := "{\"rego_version\": 1}\n"; string(contents) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, contents)
+	}
+}
+
+func TestConfigWithFailedModule(t *testing.T) {
+	t.Parallel()
+
+	dir := testutil.TempDirectoryOf(t, map[string]string{
+		".regal/config.yaml": `project:
+  rego-version: 0
+  roots:
+    - path: a
+      rego-version: 0
+    - path: b
+      rego-version: 0
+`,
+	})
+
+	contents, err := Config(filepath.Join(dir, ".regal", "config.yaml"), []string{dir}, []string{
+		filepath.Join(dir, "b", "c.rego"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `project:
+  rego-version: 0
+  roots:
+    - path: a
+      rego-version: 1
+    - path: b
+      rego-version: 0
+`
+	if string(contents) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, contents)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := testutil.TempDirectoryOf(t, map[string]string{
+		".regal/config.yaml": `project:
+  rego-version: 0 # all of it
+  roots:
+    - path: a
+      rego-version: 0
+    - path: b
+      rego-version: 0
+`,
+	})
+	path := filepath.Join(dir, ".regal", "config.yaml")
+
+	contents, err := Config(path, []string{filepath.Join(dir, "a")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `project:
+  rego-version: 0 # all of it
+  roots:
+    - path: a
+      rego-version: 1
+    - path: b
+      rego-version: 0
+`
+	if string(contents) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, contents)
+	}
+
+	contents, err = Config(path, []string{dir}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected = `project:
+  rego-version: 1 # all of it
+  roots:
+    - path: a
+      rego-version: 1
+    - path: b
+      rego-version: 1
+`
+	if string(contents) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, contents)
+	}
+}
@@ -302,3 +302,12 @@ func FreePort(preferred ...int) (port int, err error) {
 func Pointer[T any](v T) *T {
 	return &v
 }
+
+// Pluralize returns the plural form of singular, unless count is 1.
+func Pluralize(singular string, count int) string {
+	if count == 1 {
+		return singular
+	}
+
+	return singular + "s"
+}
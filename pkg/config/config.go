@@ -1,9 +1,11 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -43,6 +45,31 @@ type Config struct {
 	Project         *Project            `json:"project,omitempty"          yaml:"project,omitempty"`
 	CapabilitiesURL string              `json:"capabilities_url,omitempty" yaml:"capabilities_url,omitempty"`
 	Ignore          Ignore              `json:"ignore"                     yaml:"ignore"`
+	Overrides       []Override          `json:"overrides,omitempty"        yaml:"overrides,omitempty"`
+	// Origins maps the dot-separated path of each setting in the user config to the file it was
+	// loaded from, which may differ from the config file itself when using `extends`.
+	Origins map[string]string `json:"-" yaml:"-"`
+
+	// binaryCapabilities holds the changes to apply to the capabilities of an OPA binary, when these
+	// are to be loaded by running it. See LoadCapabilitiesFromBinary.
+	binaryCapabilities *builtinChanges
+}
+
+// builtinChanges are the built-in functions added to, and removed from, the capabilities loaded.
+type builtinChanges struct {
+	plus  []*ast.Builtin
+	minus []string
+}
+
+// apply adds and removes built-in functions from caps.
+func (c builtinChanges) apply(caps *Capabilities) {
+	for _, name := range c.minus {
+		delete(caps.Builtins, name)
+	}
+
+	for _, plusBuiltin := range c.plus {
+		caps.Builtins[plusBuiltin.Name] = fromOPABuiltin(*plusBuiltin)
+	}
 }
 
 type Root struct {
@@ -83,7 +110,24 @@ type RemoteFeatures struct {
 }
 
 type Ignore struct {
-	Files []string `json:"files,omitempty" yaml:"files,omitempty"`
+	Files      []string    `json:"files,omitempty"      yaml:"files,omitempty"`
+	Directives *Directives `json:"directives,omitempty" yaml:"directives,omitempty"`
+}
+
+// Directives configures how inline ignore directives (`# regal ignore:<rule>`) are handled.
+// This is only applicable to the top-level ignore configuration, not that of individual rules.
+type Directives struct {
+	// RequireReason when set only honors directives providing a reason, like
+	// `# regal ignore:line-length reason="generated code"`.
+	RequireReason bool `json:"require-reason,omitempty" yaml:"require-reason,omitempty"`
+}
+
+// Override applies rule configuration to files matching any of the patterns in Files, which
+// follow the same format as those of `ignore.files`. When several overrides match a file, they
+// are applied in order, so that later overrides take precedence.
+type Override struct {
+	Files []string            `json:"files"           yaml:"files"`
+	Rules map[string]Category `json:"rules,omitempty" yaml:"rules,omitempty"`
 }
 
 type ExtraAttributes map[string]any
@@ -134,11 +178,26 @@ func FromPath(path string) (Config, error) {
 	return FromFile(file)
 }
 
+// FromFile reads config from the provided file, including any config files it extends.
 func FromFile(file *os.File) (Config, error) {
-	conf := Config{}
-	err := yaml.NewDecoder(file).Decode(&conf)
+	return FromFileWithPreset(file, "")
+}
+
+// FromFileWithPreset reads config from the provided file like FromFile, but with the named preset
+// used in place of any preset set in the file. An empty preset name leaves the file as is.
+func FromFileWithPreset(file *os.File, preset string) (Config, error) {
+	source := file.Name()
+	if abs, err := filepath.Abs(source); err == nil {
+		source = abs
+	}
+
+	return fromReader(configSource{file: source}, file, preset)
+}
 
-	return conf, err
+// FromBytes reads config from the contents of a config file at path, like those of a file being edited,
+// which need not match those on disk. Relative paths of any config files extended are resolved from path.
+func FromBytes(path string, bs []byte) (Config, error) {
+	return fromReader(configSource{file: path}, bytes.NewReader(bs), "")
 }
 
 // FindConfig attempts to find either the .regal directory or .regal.yaml
@@ -482,7 +541,7 @@ func (config Config) MarshalYAML() (any, error) {
 		rawCategoryMap["default"] = categoryDefault
 	}
 
-	if len(config.Ignore.Files) == 0 {
+	if len(config.Ignore.Files) == 0 && config.Ignore.Directives == nil {
 		delete(unstructuredConfig, keyIgnore)
 	}
 
@@ -502,10 +561,12 @@ type marshallingIntermediary struct {
 	Project      *Project       `yaml:"project"`
 	Capabilities struct {
 		From struct {
-			Engine  string `yaml:"engine"`
-			Version any    `yaml:"version"`
-			File    string `yaml:"file"`
-			URL     string `yaml:"url"`
+			Engine    string `yaml:"engine"`
+			Version   any    `yaml:"version"`
+			File      string `yaml:"file"`
+			URL       string `yaml:"url"`
+			Path      string `yaml:"path"`
+			Directory string `yaml:"directory"`
 		} `yaml:"from"`
 		Plus struct {
 			Builtins []*ast.Builtin `yaml:"builtins"`
@@ -516,8 +577,9 @@ type marshallingIntermediary struct {
 			} `yaml:"builtins"`
 		} `yaml:"minus"`
 	} `yaml:"capabilities"`
-	Ignore   Ignore `yaml:"ignore"`
-	Features struct {
+	Ignore    Ignore     `yaml:"ignore"`
+	Overrides []Override `yaml:"overrides"`
+	Features  struct {
 		RemoteFeatures struct {
 			CheckVersion bool `yaml:"check-version"`
 		} `yaml:"remote"`
@@ -563,102 +625,193 @@ func (config *Config) UnmarshalYAML(value *yaml.Node) error {
 	}
 
 	config.Ignore = result.Ignore
+	config.Overrides = result.Overrides
+
+	capabilitiesURL, err := capabilitiesURLFrom(
+		result.Capabilities.From.Engine,
+		result.Capabilities.From.Version,
+		result.Capabilities.From.File,
+		result.Capabilities.From.URL,
+		result.Capabilities.From.Path,
+		result.Capabilities.From.Directory,
+	)
+	if err != nil {
+		return err
+	}
+
+	changes := builtinChanges{plus: result.Capabilities.Plus.Builtins}
+	for _, minusBuiltin := range result.Capabilities.Minus.Builtins {
+		changes.minus = append(changes.minus, minusBuiltin.Name)
+	}
+
+	// This is used in the LSP to load the OPA capabilities, since the
+	// capabilities version in the user-facing config does not contain all
+	// of the information that the LSP needs.
+	config.CapabilitiesURL = capabilitiesURL
+	config.Project = result.Project
+
+	// decoding config must not run any binary named in it, so loading the capabilities of an OPA
+	// binary is left to callers trusting the config, see LoadCapabilitiesFromBinary
+	if capabilities.IsExecURL(capabilitiesURL) {
+		config.binaryCapabilities = &changes
+	} else {
+		opaCaps, err := capabilities.Lookup(context.Background(), capabilitiesURL)
+		if err != nil {
+			return fmt.Errorf("failed to load capabilities: %w", err)
+		}
 
-	capabilitiesFile := result.Capabilities.From.File
-	capabilitiesEngine := result.Capabilities.From.Engine
-	capabilitiesEngineVersion := result.Capabilities.From.Version
-	capabilitiesURL := result.Capabilities.From.URL
+		config.Capabilities = fromOPACapabilities(opaCaps)
 
-	// Capabilities can be specified by an engine+version combo, a local
-	// file path, or a URL. These cannot be mixed and matched.
-	if capabilitiesURL != "" && capabilitiesFile != "" {
-		return errors.New("capabilities from.url and from.file are mutually exclusive")
+		changes.apply(config.Capabilities)
 	}
 
-	if capabilitiesURL != "" && capabilitiesEngine != "" {
-		return errors.New("capabilities from.url and from.engine are mutually exclusive")
+	// feature defaults
+	if result.Features.RemoteFeatures.CheckVersion {
+		config.Features = &Features{Remote: &RemoteFeatures{CheckVersion: true}}
+	}
+
+	return nil
+}
+
+// LoadCapabilitiesFromBinary loads the capabilities of the OPA binary set as capabilities.from.path
+// by running it. As this runs whatever binary the config names, it isn't done when config is decoded,
+// but only when called by those trusting the config, like the CLI linting a project. Capabilities
+// is left unset until then. Config loading capabilities from any other source is left unchanged.
+func (config *Config) LoadCapabilitiesFromBinary(ctx context.Context) error {
+	if config.binaryCapabilities == nil {
+		return nil
 	}
 
-	if capabilitiesURL != "" && capabilitiesEngineVersion != "" {
-		return errors.New("capabilities from.url and from.version are mutually exclusive")
+	opaCaps, err := capabilities.Lookup(ctx, config.CapabilitiesURL)
+	if err != nil {
+		return fmt.Errorf("failed to load capabilities: %w", err)
 	}
 
-	if capabilitiesFile != "" && capabilitiesEngine != "" {
-		return errors.New("capabilities from.file and from.engine are mutually exclusive")
+	config.Capabilities = fromOPACapabilities(opaCaps)
+
+	config.binaryCapabilities.apply(config.Capabilities)
+
+	return nil
+}
+
+// capabilitiesURLFrom returns the URL from which to load capabilities, as provided under capabilities.from.
+// Capabilities can be specified by an engine+version combo, where the version may be a range of versions,
+// a local file path, a URL, the path of an OPA binary, or a directory of capabilities files. These cannot
+// be mixed and matched, except for the engine naming the binary, and the version range filtering the files
+// of a directory.
+func capabilitiesURLFrom(engine string, engineVersion any, file, url, path, directory string) (string, error) {
+	if url != "" && file != "" {
+		return "", errors.New("capabilities from.url and from.file are mutually exclusive")
 	}
 
-	if capabilitiesEngine != "" && capabilitiesEngineVersion == "" {
-		// Although regal:///capabilities/{engine} is valid and refers
-		// to the latest version for that engine, we'll keep the
-		// existing (pre-capabilities.Lookup()) behavior in place and
-		// disallow that when using the engine key.
-		return errors.New("please set the version for the engine from which to load capabilities from")
+	if url != "" && engine != "" {
+		return "", errors.New("capabilities from.url and from.engine are mutually exclusive")
 	}
 
-	if capabilitiesEngine != "" {
-		version, ok := capabilitiesEngineVersion.(string)
-		if !ok {
-			return errors.New("capabilities: from.version must be a string")
+	if url != "" && engineVersion != "" && engineVersion != nil {
+		return "", errors.New("capabilities from.url and from.version are mutually exclusive")
+	}
+
+	if file != "" && engine != "" {
+		return "", errors.New("capabilities from.file and from.engine are mutually exclusive")
+	}
+
+	for _, other := range [][2]string{{"url", url}, {"file", file}, {"directory", directory}} {
+		if path != "" && other[1] != "" {
+			return "", fmt.Errorf("capabilities from.path and from.%s are mutually exclusive", other[0])
 		}
+	}
 
-		if capabilitiesEngine == capabilitiesEngineOPA && !strings.HasPrefix(version, "v") {
-			return errors.New("capabilities: from.version must be a valid OPA version (with a 'v' prefix)")
+	for _, other := range [][2]string{{"url", url}, {"file", file}, {"engine", engine}} {
+		if directory != "" && other[1] != "" {
+			return "", fmt.Errorf("capabilities from.directory and from.%s are mutually exclusive", other[0])
 		}
+	}
 
-		capabilitiesURL = "regal:///capabilities/" + capabilitiesEngine + "/" + version
+	if path != "" {
+		if engine != "" && engine != capabilitiesEngineOPA {
+			return "", errors.New("capabilities: from.path is only supported for the opa engine")
+		}
+
+		if engineVersion != nil {
+			return "", errors.New("capabilities from.path and from.version are mutually exclusive")
+		}
+
+		return localURL("exec", path)
 	}
 
-	if capabilitiesFile != "" {
-		absfp, err := filepath.Abs(capabilitiesFile)
-		if err != nil {
-			return fmt.Errorf(
-				"unable to load capabilities from '%s', failed to determine absolute path: %w",
-				capabilitiesFile,
-				err,
-			)
+	if directory != "" {
+		capabilitiesURL, err := localURL("file", directory)
+		if err != nil || engineVersion == nil {
+			return capabilitiesURL, err
 		}
 
-		// prepending a / is done here to ensure that windows drive letter paths
-		// are parsed as paths and not host:ports in URLs.
-		if !strings.HasPrefix(absfp, "/") {
-			absfp = "/" + absfp
+		version, ok := engineVersion.(string)
+		if !ok {
+			return "", errors.New("capabilities: from.version must be a string")
 		}
 
-		capabilitiesURL = "file://" + absfp
+		return capabilitiesURL + "?" + neturl.Values{"version": {version}}.Encode(), nil
 	}
 
-	if capabilitiesEngine == "" && capabilitiesFile == "" && capabilitiesURL == "" {
-		capabilitiesURL = capabilities.DefaultURL
+	if engine != "" && engineVersion == "" {
+		// Although regal:///capabilities/{engine} is valid and refers
+		// to the latest version for that engine, we'll keep the
+		// existing (pre-capabilities.Lookup()) behavior in place and
+		// disallow that when using the engine key.
+		return "", errors.New("please set the version for the engine from which to load capabilities from")
 	}
 
-	opaCaps, err := capabilities.Lookup(context.Background(), capabilitiesURL)
-	if err != nil {
-		return fmt.Errorf("failed to load capabilities: %w", err)
+	if engine != "" {
+		version, ok := engineVersion.(string)
+		if !ok {
+			return "", errors.New("capabilities: from.version must be a string")
+		}
+
+		if capabilities.IsVersionRange(version) {
+			if _, err := capabilities.ParseVersionRange(version); err != nil {
+				return "", fmt.Errorf("capabilities: from.version: %w", err)
+			}
+
+			return "regal:///capabilities/" + engine + "/" + neturl.PathEscape(version), nil
+		}
+
+		if engine == capabilitiesEngineOPA && !strings.HasPrefix(version, "v") {
+			return "", errors.New("capabilities: from.version must be a valid OPA version (with a 'v' prefix)")
+		}
+
+		return "regal:///capabilities/" + engine + "/" + version, nil
 	}
 
-	// This is used in the LSP to load the OPA capabilities, since the
-	// capabilities version in the user-facing config does not contain all
-	// of the information that the LSP needs.
-	config.CapabilitiesURL = capabilitiesURL
-	config.Capabilities = fromOPACapabilities(opaCaps)
-	config.Project = result.Project
+	if file != "" {
+		return localURL("file", file)
+	}
 
-	// remove any builtins referenced in the minus config
-	for _, minusBuiltin := range result.Capabilities.Minus.Builtins {
-		delete(config.Capabilities.Builtins, minusBuiltin.Name)
+	if url == "" {
+		return capabilities.DefaultURL, nil
 	}
 
-	// add any builtins referenced in the plus config
-	for _, plusBuiltin := range result.Capabilities.Plus.Builtins {
-		config.Capabilities.Builtins[plusBuiltin.Name] = fromOPABuiltin(*plusBuiltin)
+	return url, nil
+}
+
+// localURL returns a URL with the scheme provided for the absolute path of the local file or directory.
+func localURL(scheme, path string) (string, error) {
+	absfp, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf(
+			"unable to load capabilities from '%s', failed to determine absolute path: %w",
+			path,
+			err,
+		)
 	}
 
-	// feature defaults
-	if result.Features.RemoteFeatures.CheckVersion {
-		config.Features = &Features{Remote: &RemoteFeatures{CheckVersion: true}}
+	// prepending a / is done here to ensure that windows drive letter paths
+	// are parsed as paths and not host:ports in URLs.
+	if !strings.HasPrefix(absfp, "/") {
+		absfp = "/" + absfp
 	}
 
-	return nil
+	return scheme + "://" + absfp, nil
 }
 
 // extractRules is a helper to load rules from the raw config data.
@@ -787,6 +940,28 @@ func ToMap(config Config) map[string]any {
 		}
 	}
 
+	// Overrides commonly change only some attributes of a rule, and an empty level must not
+	// replace the level of the rule when the override is applied.
+	for i, override := range config.Overrides {
+		//nolint:forcetypeassert
+		rawRules, _ := confMap["overrides"].([]any)[i].(map[string]any)["rules"].(map[string]any)
+
+		for categoryName, category := range override.Rules {
+			for ruleName, rule := range category {
+				//nolint:forcetypeassert
+				ruleMap := rawRules[categoryName].(map[string]any)[ruleName].(map[string]any)
+
+				if rule.Ignore == nil {
+					delete(ruleMap, keyIgnore)
+				}
+
+				if rule.Level == "" {
+					delete(ruleMap, keyLevel)
+				}
+			}
+		}
+	}
+
 	return confMap
 }
 
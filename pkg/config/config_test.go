@@ -2,7 +2,9 @@ package config
 
 import (
 	"maps"
+	"os"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strings"
 	"testing"
@@ -230,6 +232,27 @@ func TestMarshalConfig(t *testing.T) {
 	}
 }
 
+func TestUnmarshalMarshalConfigIgnoreDirectives(t *testing.T) {
+	t.Parallel()
+
+	bs := []byte(`ignore:
+    directives:
+        require-reason: true
+`)
+
+	conf := testutil.MustUnmarshalYAML[Config](t, bs)
+
+	if conf.Ignore.Directives == nil || !conf.Ignore.Directives.RequireReason {
+		t.Fatalf("expected ignore.directives.require-reason to be true, got %v", conf.Ignore.Directives)
+	}
+
+	conf.Capabilities = nil
+
+	if roundTripped := string(testutil.Must(yaml.Marshal(conf))(t)); roundTripped != string(bs)+"rules: {}\n" {
+		t.Errorf("expected:\n%sgot:\n%s", bs, roundTripped)
+	}
+}
+
 func TestUnmarshalMarshalConfigWithDefaultRuleConfigs(t *testing.T) {
 	t.Parallel()
 
@@ -448,6 +471,119 @@ capabilities:
 	}
 }
 
+func TestUnmarshalConfigWithOPAVersionRange(t *testing.T) {
+	t.Parallel()
+
+	bs := []byte(`rules: {}
+capabilities:
+  from:
+    engine: opa
+    version: ">=0.55 <=0.60"
+`)
+
+	conf := testutil.MustUnmarshalYAML[Config](t, bs)
+
+	if exp, got := "regal:///capabilities/opa/%3E=0.55%20%3C=0.60", conf.CapabilitiesURL; exp != got {
+		t.Errorf("expected capabilities URL %s, got %s", exp, got)
+	}
+
+	if exp, got := 193, len(conf.Capabilities.Builtins); exp != got {
+		t.Errorf("expected %d builtins, got %d", exp, got)
+	}
+}
+
+func TestUnmarshalConfigWithCapabilitiesDirectory(t *testing.T) {
+	t.Parallel()
+
+	bs := []byte(`rules: {}
+capabilities:
+  from:
+    directory: ./fixtures
+    version: ">=1.0"
+`)
+
+	// no capabilities files in the fixtures directory are named by versions in the range
+	if err := yaml.Unmarshal(bs, &Config{}); err == nil ||
+		!strings.Contains(err.Error(), "no capabilities files found in directory") {
+		t.Errorf("expected error, got %v", err)
+	}
+}
+
+func TestCapabilitiesFromBinaryRelativeToConfigFile(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell script in place of an OPA binary")
+	}
+
+	caps := testutil.MustReadFile(t, filepath.Join("fixtures", "caps.json"))
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"project/.regal.yaml": "capabilities:\n  from:\n    path: ./bin/opa\n",
+		"project/caps.json":   string(caps),
+	})
+
+	marker := filepath.Join(root, "project", "ran")
+	script := `#!/bin/sh
+touch ` + marker + `
+cat ` + filepath.Join(root, "project", "caps.json") + "\n"
+
+	testutil.MustMkdirAll(t, root, "project", "bin")
+
+	if err := os.WriteFile(filepath.Join(root, "project", "bin", "opa"), []byte(script), 0o700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	configFile := filepath.Join(root, "project", ".regal.yaml")
+
+	// reading config never runs the binary named in it
+	for _, read := range []func() (Config, error){
+		func() (Config, error) { return FromBytes(configFile, testutil.MustReadFile(t, configFile)) },
+		func() (Config, error) { return FromPath(configFile) },
+	} {
+		conf := testutil.Must(read())(t)
+
+		if exp, got := "exec://"+filepath.Join(root, "project", "bin", "opa"), conf.CapabilitiesURL; exp != got {
+			t.Errorf("expected capabilities URL %s, got %s", exp, got)
+		}
+
+		if conf.Capabilities != nil {
+			t.Errorf("expected no capabilities to be loaded, got %v", conf.Capabilities)
+		}
+	}
+
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Fatalf("expected binary not to be run when reading config")
+	}
+
+	conf := testutil.Must(FromPath(configFile))(t)
+	if err := conf.LoadCapabilitiesFromBinary(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Contains(outil.Keys(conf.Capabilities.Builtins), "wow") {
+		t.Errorf("expected builtin 'wow' from the capabilities printed by the binary")
+	}
+}
+
+func TestUnmarshalConfigWithMutuallyExclusiveCapabilities(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"path: ./opa\n    file: caps.json":        "capabilities from.path and from.file are mutually exclusive",
+		"path: ./opa\n    version: v1.0.0":        "capabilities from.path and from.version are mutually exclusive",
+		"path: ./opa\n    engine: eopa":           "capabilities: from.path is only supported for the opa engine",
+		"directory: ./caps\n    engine: opa":      "capabilities from.directory and from.engine are mutually exclusive",
+		"directory: ./caps\n    url: https://x.y": "capabilities from.directory and from.url are mutually exclusive",
+	}
+
+	for from, expected := range cases {
+		bs := []byte("capabilities:\n  from:\n    " + from + "\n")
+		if err := yaml.Unmarshal(bs, &Config{}); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q, got %v", expected, err)
+		}
+	}
+}
+
 func TestUnmarshalProjectRootsAsStringOrObject(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,329 @@
+package config
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	keyExtends = "extends"
+
+	// archiveSeparator separates the path of an archive from the path of a config file inside it,
+	// as in `extends: shared/regal.tar.gz#configs/base.yaml`.
+	archiveSeparator = "#"
+)
+
+// configSource identifies a config file, either on disk, or inside a tarball on disk.
+type configSource struct {
+	// file is the path of the config file, or the archive containing it, on disk
+	file string
+	// member is the slash-separated path of the config file inside the archive, if any
+	member string
+}
+
+func (s configSource) String() string {
+	if s.member == "" {
+		return s.file
+	}
+
+	return s.file + archiveSeparator + s.member
+}
+
+// resolve returns the source referenced from an extends entry in this source. Relative paths are
+// resolved from the directory of this source, which for config files in an archive is a directory
+// in that archive.
+func (s configSource) resolve(ref string) configSource {
+	if file, member, ok := strings.Cut(ref, archiveSeparator); ok && isArchive(file) {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(s.file), file)
+		}
+
+		return configSource{file: filepath.Clean(file), member: path.Clean(member)}
+	}
+
+	if s.member != "" {
+		return configSource{file: s.file, member: path.Join(path.Dir(s.member), ref)}
+	}
+
+	if !filepath.IsAbs(ref) {
+		ref = filepath.Join(filepath.Dir(s.file), ref)
+	}
+
+	return configSource{file: filepath.Clean(ref)}
+}
+
+// resolveCapabilitiesPaths resolves the relative paths of any OPA binary or directory of capabilities
+// files set in the values of this source from the directory of the source, so that these are found
+// no matter the directory Regal is run from, or which config file extends this one.
+func (s configSource) resolveCapabilitiesPaths(values map[string]any) {
+	if s.file == "" || s.member != "" {
+		return
+	}
+
+	caps, _ := values["capabilities"].(map[string]any)
+	from, _ := caps["from"].(map[string]any)
+
+	for _, key := range []string{"path", "directory"} {
+		if p, ok := from[key].(string); ok && p != "" && !filepath.IsAbs(p) {
+			from[key] = filepath.Join(filepath.Dir(s.file), p)
+		}
+	}
+}
+
+func (s configSource) read() ([]byte, error) {
+	if s.member == "" {
+		return os.ReadFile(s.file) //nolint:wrapcheck
+	}
+
+	return readArchiveMember(s.file, s.member)
+}
+
+// configLayer holds the raw contents of a config file merged with those of the files it extends,
+// along with the source of each setting, keyed by its dot-separated path in the config.
+type configLayer struct {
+	values  map[string]any
+	origins map[string]string
+}
+
+// fromReader decodes config read from the source, with the files listed under `extends` merged
+// in first, so that the settings of the extending file take precedence. Files are merged in the
+// order listed, each one overriding the settings of those before it. Maps are merged recursively,
+// while any other values, including lists, are replaced. A preset, if given, replaces any preset
+// set in the source. The config of a preset is merged in before that of any extended files.
+func fromReader(source configSource, r io.Reader, preset string) (Config, error) {
+	var values map[string]any
+	if err := yaml.NewDecoder(r).Decode(&values); err != nil {
+		return Config{}, err //nolint:wrapcheck
+	}
+
+	if values == nil {
+		values = make(map[string]any)
+	}
+
+	if preset != "" {
+		values[keyPreset] = preset
+	}
+
+	return fromValues(source, values)
+}
+
+func fromValues(source configSource, values map[string]any) (Config, error) {
+	layer, err := resolveExtends(source, values, nil)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var node yaml.Node
+	if err := node.Encode(layer.values); err != nil {
+		return Config{}, fmt.Errorf("failed to encode merged config: %w", err)
+	}
+
+	conf := Config{}
+	if err := node.Decode(&conf); err != nil {
+		return Config{}, err //nolint:wrapcheck
+	}
+
+	conf.Origins = layer.origins
+
+	return conf, nil
+}
+
+func resolveExtends(source configSource, values map[string]any, seen []string) (configLayer, error) {
+	if slices.Contains(seen, source.String()) {
+		return configLayer{}, fmt.Errorf("cycle in config extends: %s", strings.Join(append(seen, source.String()), " -> "))
+	}
+
+	seen = append(seen, source.String())
+
+	refs, err := extendsRefs(values[keyExtends])
+	if err != nil {
+		return configLayer{}, fmt.Errorf("invalid extends in %s: %w", source, err)
+	}
+
+	delete(values, keyExtends)
+
+	merged := configLayer{values: make(map[string]any), origins: make(map[string]string)}
+
+	if preset, ok := values[keyPreset]; ok {
+		name, ok := preset.(string)
+		if !ok {
+			return configLayer{}, fmt.Errorf("invalid preset in %s: expected a string, got %T", source, preset)
+		}
+
+		presetValues, err := presetValues(name)
+		if err != nil {
+			return configLayer{}, fmt.Errorf("invalid preset in %s: %w", source, err)
+		}
+
+		layer := configLayer{values: presetValues, origins: make(map[string]string)}
+		layer.setOrigin(presetValues, "", "preset "+name)
+
+		merged.merge(layer, "")
+
+		delete(values, keyPreset)
+	}
+
+	for _, ref := range refs {
+		extended := source.resolve(ref)
+
+		bs, err := extended.read()
+		if err != nil {
+			return configLayer{}, fmt.Errorf("failed to read config %s extended by %s: %w", extended, source, err)
+		}
+
+		var extendedValues map[string]any
+		if err := yaml.Unmarshal(bs, &extendedValues); err != nil {
+			return configLayer{}, fmt.Errorf("failed to decode config %s extended by %s: %w", extended, source, err)
+		}
+
+		layer, err := resolveExtends(extended, extendedValues, seen)
+		if err != nil {
+			return configLayer{}, err
+		}
+
+		merged.merge(layer, "")
+	}
+
+	source.resolveCapabilitiesPaths(values)
+
+	own := configLayer{values: values, origins: make(map[string]string)}
+	own.setOrigin(values, "", source.String())
+
+	merged.merge(own, "")
+
+	return merged, nil
+}
+
+// extendsRefs returns the config files listed under `extends`, which may be a single string or a list of strings.
+func extendsRefs(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		refs := make([]string, 0, len(v))
+
+		for _, item := range v {
+			ref, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got item of type %T", item)
+			}
+
+			refs = append(refs, ref)
+		}
+
+		return refs, nil
+	default:
+		return nil, fmt.Errorf("expected a string or a list of strings, got %T", value)
+	}
+}
+
+// merge merges src into the layer, replacing any values and origins found at the same path.
+func (l *configLayer) merge(src configLayer, prefix string) {
+	l.mergeMap(l.values, src, src.values, prefix)
+}
+
+func (l *configLayer) mergeMap(dst map[string]any, src configLayer, values map[string]any, prefix string) {
+	for key, value := range values {
+		keyPath := joinKeyPath(prefix, key)
+
+		if srcMap, ok := value.(map[string]any); ok {
+			if dstMap, ok := dst[key].(map[string]any); ok {
+				l.mergeMap(dstMap, src, srcMap, keyPath)
+
+				continue
+			}
+		}
+
+		for p := range l.origins {
+			if p == keyPath || strings.HasPrefix(p, keyPath+".") {
+				delete(l.origins, p)
+			}
+		}
+
+		dst[key] = value
+
+		for p, origin := range src.origins {
+			if p == keyPath || strings.HasPrefix(p, keyPath+".") {
+				l.origins[p] = origin
+			}
+		}
+	}
+}
+
+// setOrigin records origin as the source of all settings found in value.
+func (l *configLayer) setOrigin(value any, prefix, origin string) {
+	if m, ok := value.(map[string]any); ok && len(m) > 0 {
+		for key, v := range m {
+			l.setOrigin(v, joinKeyPath(prefix, key), origin)
+		}
+
+		return
+	}
+
+	if prefix != "" {
+		l.origins[prefix] = origin
+	}
+}
+
+func joinKeyPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+func isArchive(file string) bool {
+	return strings.HasSuffix(file, ".tar") || strings.HasSuffix(file, ".tar.gz") || strings.HasSuffix(file, ".tgz")
+}
+
+func readArchiveMember(file, member string) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	defer f.Close()
+
+	var r io.Reader = f
+
+	if !strings.HasSuffix(file, ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzipped archive: %w", err)
+		}
+
+		defer gz.Close()
+
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("file %s not found in archive", member)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		if header.Typeflag == tar.TypeReg && path.Clean(strings.TrimPrefix(header.Name, "./")) == member {
+			return io.ReadAll(tr) //nolint:wrapcheck
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-policy-agent/opa/v1/util/test"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestFromPathWithExtends(t *testing.T) {
+	t.Parallel()
+
+	fs := map[string]string{
+		"/shared/base.yaml": `rules:
+  style:
+    line-length:
+      level: warning
+      max-line-length: 100
+    todo-comment:
+      level: ignore
+ignore:
+  files:
+  - base.rego
+`,
+		"/shared/strict.yaml": `extends: base.yaml
+rules:
+  style:
+    line-length:
+      max-line-length: 80
+`,
+		"/repo/.regal.yaml": `extends:
+- ../shared/strict.yaml
+rules:
+  style:
+    todo-comment:
+      level: error
+ignore:
+  files:
+  - repo.rego
+`,
+	}
+
+	test.WithTempFS(fs, func(root string) {
+		conf := testutil.Must(FromPath(filepath.Join(root, "repo", ".regal.yaml")))(t)
+
+		lineLength := conf.Rules["style"]["line-length"]
+		if lineLength.Level != "warning" || lineLength.Extra["max-line-length"] != 80 {
+			t.Errorf("expected line-length level warning and max-line-length 80, got %v", lineLength)
+		}
+
+		if conf.Rules["style"]["todo-comment"].Level != levelError {
+			t.Errorf("expected todo-comment level error, got %v", conf.Rules["style"]["todo-comment"])
+		}
+
+		// lists are replaced, not appended
+		if len(conf.Ignore.Files) != 1 || conf.Ignore.Files[0] != "repo.rego" {
+			t.Errorf("expected ignore files [repo.rego], got %v", conf.Ignore.Files)
+		}
+
+		expectedOrigins := map[string]string{
+			"rules.style.line-length.level":           filepath.Join(root, "shared", "base.yaml"),
+			"rules.style.line-length.max-line-length": filepath.Join(root, "shared", "strict.yaml"),
+			"rules.style.todo-comment.level":          filepath.Join(root, "repo", ".regal.yaml"),
+			"ignore.files":                            filepath.Join(root, "repo", ".regal.yaml"),
+		}
+
+		for key, expected := range expectedOrigins {
+			if conf.Origins[key] != expected {
+				t.Errorf("expected %s to be set by %s, got %s", key, expected, conf.Origins[key])
+			}
+		}
+
+		if len(conf.Origins) != len(expectedOrigins) {
+			t.Errorf("expected %d origins, got %v", len(expectedOrigins), conf.Origins)
+		}
+	})
+}
+
+func TestFromPathWithExtendsFromArchive(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	writeTarball(t, filepath.Join(root, "regal.tar.gz"), map[string]string{
+		"configs/base.yaml": "rules:\n  style:\n    line-length:\n      level: warning\n",
+		"configs/team.yaml": "extends: base.yaml\nrules:\n  style:\n    todo-comment:\n      level: ignore\n",
+	})
+
+	configPath := filepath.Join(root, ".regal.yaml")
+	testutil.MustWriteFile(t, configPath, []byte("extends: regal.tar.gz#configs/team.yaml\n"))
+
+	conf := testutil.Must(FromPath(configPath))(t)
+
+	if conf.Rules["style"]["line-length"].Level != "warning" {
+		t.Errorf("expected line-length level warning, got %v", conf.Rules["style"]["line-length"])
+	}
+
+	if conf.Rules["style"]["todo-comment"].Level != "ignore" {
+		t.Errorf("expected todo-comment level ignore, got %v", conf.Rules["style"]["todo-comment"])
+	}
+
+	expected := filepath.Join(root, "regal.tar.gz") + "#configs/base.yaml"
+	if origin := conf.Origins["rules.style.line-length.level"]; origin != expected {
+		t.Errorf("expected line-length level to be set by %s, got %s", expected, origin)
+	}
+}
+
+func TestFromPathWithExtendsCycle(t *testing.T) {
+	t.Parallel()
+
+	fs := map[string]string{
+		"/a.yaml": "extends: b.yaml\n",
+		"/b.yaml": "extends: [c.yaml, a.yaml]\n",
+		"/c.yaml": "rules: {}\n",
+	}
+
+	test.WithTempFS(fs, func(root string) {
+		_, err := FromPath(filepath.Join(root, "a.yaml"))
+		if err == nil {
+			t.Fatal("expected error for cycle in extends")
+		}
+
+		a, b := filepath.Join(root, "a.yaml"), filepath.Join(root, "b.yaml")
+		if expected := "cycle in config extends: " + a + " -> " + b + " -> " + a; !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got %q", expected, err.Error())
+		}
+	})
+}
+
+func writeTarball(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	f := testutil.Must(os.Create(path))(t)
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	for name, contents := range files {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(contents)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tw.Write([]byte(contents)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	listIndex   = regexp.MustCompile(`^(.+)\[(\d+)\]$`)
+	listIndexes = regexp.MustCompile(`\[\d+\]`)
+)
+
+// Locate returns the key and value nodes of the setting at the dot-separated path in a YAML document,
+// where items of lists are referenced by index, like overrides[0].files. For items of lists, the key
+// node returned is the item itself. Nil is returned for both if the path is not found in the document.
+func Locate(doc *yaml.Node, path string) (*yaml.Node, *yaml.Node) {
+	node := doc
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	var key *yaml.Node
+
+	for segment := range strings.SplitSeq(path, ".") {
+		name, index := segment, -1
+		if match := listIndex.FindStringSubmatch(segment); match != nil {
+			name = match[1]
+			index, _ = strconv.Atoi(match[2])
+		}
+
+		key, node = mappingEntry(node, name)
+		if node == nil {
+			return nil, nil
+		}
+
+		if index >= 0 {
+			if node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+				return nil, nil
+			}
+
+			node = node.Content[index]
+			key = node
+		}
+	}
+
+	return key, node
+}
+
+// Location returns the file in which the setting at the dot-separated path of the user configuration
+// was set, as found in Origins, and the line of the setting in that file. The line is 0 when it can't
+// be determined, like for settings of presets, and the file is empty when the origin is not known.
+func (c *Config) Location(path string) (string, int) {
+	origin := c.origin(path)
+	if origin == "" || strings.HasPrefix(origin, "preset ") {
+		return origin, 0
+	}
+
+	source := configSource{file: origin}
+	if file, member, ok := strings.Cut(origin, archiveSeparator); ok && isArchive(file) {
+		source = configSource{file: file, member: member}
+	}
+
+	bs, err := source.read()
+	if err != nil {
+		return origin, 0
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bs, &doc); err != nil {
+		return origin, 0
+	}
+
+	// settings not found may be found in part, like an unknown attribute of a nested object
+	for p := path; p != ""; p = parentPath(p) {
+		if key, _ := Locate(&doc, p); key != nil {
+			return origin, key.Line
+		}
+	}
+
+	return origin, 0
+}
+
+// origin returns the origin of the setting at path, or of the closest setting found below or above it.
+func (c *Config) origin(path string) string {
+	key := listIndexes.ReplaceAllString(path, "")
+
+	if origin, ok := c.Origins[key]; ok {
+		return origin
+	}
+
+	for p, origin := range c.Origins {
+		if strings.HasPrefix(p, key+".") {
+			return origin
+		}
+	}
+
+	for p := parentPath(key); p != ""; p = parentPath(p) {
+		if origin, ok := c.Origins[p]; ok {
+			return origin
+		}
+	}
+
+	return ""
+}
+
+func parentPath(path string) string {
+	if i := strings.LastIndex(path, "."); i > 0 {
+		return path[:i]
+	}
+
+	return ""
+}
+
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
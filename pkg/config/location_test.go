@@ -0,0 +1,63 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-policy-agent/opa/v1/util/test"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestLocation(t *testing.T) {
+	t.Parallel()
+
+	fs := map[string]string{
+		"/shared/base.yaml": `rules:
+  acme:
+    my-rule:
+      max-things: 5
+`,
+		"/repo/.regal.yaml": `extends: ../shared/base.yaml
+preset: minimal
+rules:
+  acme:
+    my-rule:
+      level: error
+      names:
+      - foo
+      - bar
+overrides:
+- files:
+  - generated/**
+  rules:
+    acme:
+      my-rule:
+        max-things: 10
+`,
+	}
+
+	test.WithTempFS(fs, func(root string) {
+		conf := testutil.Must(FromPath(filepath.Join(root, "repo", ".regal.yaml")))(t)
+
+		testCases := []struct {
+			path string
+			file string
+			line int
+		}{
+			{"rules.acme.my-rule.max-things", filepath.Join(root, "shared", "base.yaml"), 4},
+			{"rules.acme.my-rule.level", filepath.Join(root, "repo", ".regal.yaml"), 6},
+			{"rules.acme.my-rule.names[1]", filepath.Join(root, "repo", ".regal.yaml"), 9},
+			{"rules.acme.my-rule.names[1].unknown", filepath.Join(root, "repo", ".regal.yaml"), 9},
+			{"overrides[0].rules.acme.my-rule.max-things", filepath.Join(root, "repo", ".regal.yaml"), 16},
+			{"rules.bugs.default.level", "preset minimal", 0},
+			{"capabilities.from", "", 0},
+		}
+
+		for _, tc := range testCases {
+			if file, line := conf.Location(tc.path); file != tc.file || line != tc.line {
+				t.Errorf("expected %s to be located at %s:%d, got %s:%d", tc.path, tc.file, tc.line, file, line)
+			}
+		}
+	})
+}
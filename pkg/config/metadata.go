@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+	"github.com/open-policy-agent/regal/pkg/roast/rast"
+)
+
+// RuleMetadata is the metadata of a rule relevant to its configuration, as found in the METADATA
+// annotation of the package of the rule.
+type RuleMetadata struct {
+	Description string
+	// Config holds schemas of the attributes a rule may be configured with besides those of the
+	// provided configuration, as declared under `config` in the custom section of the annotation.
+	Config map[string]*Schema
+	// Schema is the schema of the complete configuration of a custom rule, when provided in a
+	// sidecar file next to the rule.
+	Schema *Schema
+}
+
+// ConfigSchema returns the schema that the configuration of a custom rule is validated against, or
+// nil if the rule declares none. A schema provided in a sidecar file is used as is, while attributes
+// declared in the annotation make up a schema allowing only those attributes. Level and ignored files
+// are handled by Regal, and are not included in the validation.
+func (m RuleMetadata) ConfigSchema() *Schema {
+	switch {
+	case m.Schema != nil:
+		return m.Schema
+	case len(m.Config) > 0:
+		return objectSchema("", m.Config)
+	default:
+		return nil
+	}
+}
+
+// RuleMetadataFromModules returns the metadata of the built-in and custom rules found among the
+// modules, keyed by category and title. Modules of packages other than those of rules are skipped.
+func RuleMetadataFromModules(modules ...*ast.Module) (map[string]map[string]RuleMetadata, error) {
+	metadata := make(map[string]map[string]RuleMetadata)
+
+	for _, module := range modules {
+		parts := rast.UnquotedPath(module.Package.Path)
+		if len(parts) == 5 && parts[0] == "custom" {
+			parts = parts[1:]
+		}
+
+		// regal.rules.category.title
+		if len(parts) != 4 || parts[0] != "regal" || parts[1] != "rules" {
+			continue
+		}
+
+		var rule RuleMetadata
+
+		for _, annotations := range module.Annotations {
+			if annotations.Scope != "package" {
+				continue
+			}
+
+			rule.Description = annotations.Description
+
+			if cfg, ok := annotations.Custom["config"]; ok {
+				if err := encoding.JSONRoundTrip(cfg, &rule.Config); err != nil {
+					return nil, fmt.Errorf("invalid config schema in metadata of rule %s: %w", parts[3], err)
+				}
+			}
+		}
+
+		if metadata[parts[2]] == nil {
+			metadata[parts[2]] = make(map[string]RuleMetadata)
+		}
+
+		metadata[parts[2]][parts[3]] = rule
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/regal/internal/io/files"
+	"github.com/open-policy-agent/regal/internal/io/files/filter"
+)
+
+// AddNestedConfigOverrides adds the rule configuration of any .regal.yaml files found in directories
+// below the project root as overrides for the files in those directories. The project root is the
+// directory of a .regal.yaml file, or the directory containing a .regal directory. Config read from
+// any other file, like the global config file, is left unchanged.
+func AddNestedConfigOverrides(conf *Config, configPath string) error {
+	root := NestedConfigRoot(configPath)
+	if root == "" {
+		return nil
+	}
+
+	overrides, err := NestedConfigOverrides(root, conf.Ignore.Files)
+	if err != nil {
+		return fmt.Errorf("failed to load nested config files: %w", err)
+	}
+
+	conf.Overrides = append(conf.Overrides, overrides...)
+
+	return nil
+}
+
+// NestedConfigRoot returns the project root below which nested config files apply for the config
+// file at configPath, or an empty string if nested config files don't apply to it.
+func NestedConfigRoot(configPath string) string {
+	root := filepath.Dir(configPath)
+
+	switch {
+	case filepath.Base(configPath) == standaloneConfigFileName:
+		return root
+	case filepath.Base(configPath) == configFileName && filepath.Base(root) == regalDirName:
+		return filepath.Dir(root)
+	default:
+		return ""
+	}
+}
+
+// NestedConfigOverrides finds .regal.yaml files in the directories below root, and returns the rule
+// configuration of each as an override applying to the files in its directory. Overrides for more
+// deeply nested directories are returned last, so that they take precedence over those above them.
+// Directories matching the ignore patterns, like those of ignore.files, are not searched.
+func NestedConfigOverrides(root string, ignore []string) ([]Override, error) {
+	var (
+		paths   []string
+		skipErr error
+	)
+
+	walker := files.DefaultWalker(root).WithSkipFunc(func(path string, d fs.DirEntry) bool {
+		if filter.DefaultSkipDirectories(path, d) {
+			return true
+		}
+
+		if !d.IsDir() || len(ignore) == 0 || path == root {
+			return false
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return false
+		}
+
+		kept, err := filterPaths([]string{filepath.ToSlash(rel) + "/"}, ignore, "")
+		if err != nil {
+			skipErr = err
+
+			return true
+		}
+
+		return len(kept) == 0
+	})
+
+	if err := walker.Walk(func(path string) error {
+		if filepath.Base(path) == standaloneConfigFileName && filepath.Dir(path) != filepath.Clean(root) {
+			paths = append(paths, path)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to walk %s for nested config files: %w", root, err)
+	}
+
+	if skipErr != nil {
+		return nil, fmt.Errorf("failed to walk %s for nested config files: %w", root, skipErr)
+	}
+
+	slices.SortFunc(paths, func(a, b string) int {
+		sep := string(filepath.Separator)
+
+		return cmp.Or(cmp.Compare(strings.Count(a, sep), strings.Count(b, sep)), strings.Compare(a, b))
+	})
+
+	overrides := make([]Override, 0, len(paths))
+
+	for _, path := range paths {
+		conf, err := FromPath(path)
+		if errors.Is(err, io.EOF) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read nested config file %s: %w", path, err)
+		}
+
+		rel, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine directory of nested config file %s: %w", path, err)
+		}
+
+		// the leading slash anchors the pattern to the root, rather than matching directories of the same name anywhere
+		overrides = append(overrides, Override{
+			Files: []string{"/" + filepath.ToSlash(rel) + "/"},
+			Rules: conf.Rules,
+		})
+	}
+
+	return overrides, nil
+}
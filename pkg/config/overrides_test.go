@@ -0,0 +1,102 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/open-policy-agent/opa/v1/util/test"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestUnmarshalConfigOverrides(t *testing.T) {
+	t.Parallel()
+
+	conf := testutil.MustUnmarshalYAML[Config](t, []byte(`
+overrides:
+- files:
+  - generated/**
+  rules:
+    style:
+      line-length:
+        max-line-length: 200
+- files:
+  - scratch/**
+  rules:
+    testing:
+      print-or-trace-call:
+        level: ignore
+`))
+
+	if len(conf.Overrides) != 2 {
+		t.Fatalf("expected 2 overrides, got %d", len(conf.Overrides))
+	}
+
+	if !slices.Equal(conf.Overrides[0].Files, []string{"generated/**"}) {
+		t.Errorf("expected files [generated/**], got %v", conf.Overrides[0].Files)
+	}
+
+	if conf.Overrides[1].Rules["testing"]["print-or-trace-call"].Level != "ignore" {
+		t.Errorf("expected print-or-trace-call level ignore, got %v", conf.Overrides[1].Rules)
+	}
+
+	// an unset level must not be included, or it would replace the level of the rule
+	overrides, _ := ToMap(conf)["overrides"].([]any)
+	//nolint:forcetypeassert
+	rule := overrides[0].(map[string]any)["rules"].(map[string]any)["style"].(map[string]any)["line-length"].(map[string]any)
+
+	if _, ok := rule["level"]; ok || len(rule) != 1 {
+		t.Errorf("expected line-length override to only set max-line-length, got %v", rule)
+	}
+}
+
+func TestNestedConfigOverrides(t *testing.T) {
+	t.Parallel()
+
+	fs := map[string]string{
+		"/.regal.yaml":               "rules: {}\n",
+		"/lib/.regal.yaml":           "rules:\n  style:\n    line-length:\n      level: warning\n",
+		"/lib/generated/.regal.yaml": "rules:\n  style:\n    line-length:\n      level: ignore\n",
+		"/empty/.regal.yaml":         "",
+		"/lib/p.rego":                "package lib\n",
+	}
+
+	test.WithTempFS(fs, func(root string) {
+		overrides := testutil.Must(NestedConfigOverrides(root, nil))(t)
+
+		if len(overrides) != 2 {
+			t.Fatalf("expected 2 overrides, got %v", overrides)
+		}
+
+		if !slices.Equal(overrides[0].Files, []string{"/lib/"}) ||
+			overrides[0].Rules["style"]["line-length"].Level != "warning" {
+			t.Errorf("expected first override for /lib/ with level warning, got %v", overrides[0])
+		}
+
+		if !slices.Equal(overrides[1].Files, []string{filepath.ToSlash("/lib/generated/")}) ||
+			overrides[1].Rules["style"]["line-length"].Level != "ignore" {
+			t.Errorf("expected second override for /lib/generated/ with level ignore, got %v", overrides[1])
+		}
+	})
+}
+
+func TestNestedConfigOverridesSkipsIgnoredDirectories(t *testing.T) {
+	t.Parallel()
+
+	fs := map[string]string{
+		"/.regal.yaml":                  "rules: {}\n",
+		"/lib/.regal.yaml":              "rules:\n  style:\n    line-length:\n      level: warning\n",
+		"/vendor/.regal.yaml":           "rules: [\n", // would fail to decode if read
+		"/lib/build/out/.regal.yaml":    "rules:\n  style:\n    line-length:\n      level: ignore\n",
+		"/node_modules/dep/.regal.yaml": "rules:\n  style:\n    line-length:\n      level: ignore\n",
+	}
+
+	test.WithTempFS(fs, func(root string) {
+		overrides := testutil.Must(NestedConfigOverrides(root, []string{"/vendor/", "build"}))(t)
+
+		if len(overrides) != 1 || !slices.Equal(overrides[0].Files, []string{"/lib/"}) {
+			t.Errorf("expected only override for /lib/, got %v", overrides)
+		}
+	})
+}
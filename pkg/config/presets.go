@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	rbundle "github.com/open-policy-agent/regal/bundle"
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+)
+
+const keyPreset = "preset"
+
+// FromPreset returns the config of the named preset, to be used as user config when no config file is found.
+func FromPreset(name string) (Config, error) {
+	if _, err := presetValues(name); err != nil {
+		return Config{}, err
+	}
+
+	return fromValues(configSource{}, map[string]any{keyPreset: name})
+}
+
+// Presets returns the names of the presets provided by Regal, in sorted order.
+func Presets() []string {
+	return slices.Sorted(maps.Keys(providedPresets()))
+}
+
+// presetValues returns a copy of the raw config of the named preset, as provided in the
+// regal.config.provided.presets data of the Regal bundle.
+func presetValues(name string) (map[string]any, error) {
+	preset, ok := providedPresets()[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %s, expected one of %s", name, strings.Join(Presets(), ", "))
+	}
+
+	// the preset is copied, as merging modifies maps in place
+	var values map[string]any
+	if err := encoding.JSONRoundTrip(preset, &values); err != nil {
+		return nil, fmt.Errorf("failed to read preset %s: %w", name, err)
+	}
+
+	return values, nil
+}
+
+func providedPresets() map[string]any {
+	presets, err := util.SearchMap(rbundle.LoadedBundle().Data, "regal", "config", "provided", "presets")
+	if err != nil {
+		return nil
+	}
+
+	m, _ := presets.(map[string]any)
+
+	return m
+}
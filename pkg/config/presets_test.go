@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/open-policy-agent/opa/v1/util/test"
+
+	rbundle "github.com/open-policy-agent/regal/bundle"
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestPresetsAreValid(t *testing.T) {
+	t.Parallel()
+
+	expected := []string{"minimal", "opa-v1-migration", "recommended", "strict"}
+	if presets := Presets(); !slices.Equal(presets, expected) {
+		t.Fatalf("expected presets %v, got %v", expected, presets)
+	}
+
+	provided := testutil.Must(LoadConfigWithDefaultsFromBundle(rbundle.LoadedBundle(), nil))(t)
+
+	for _, preset := range Presets() {
+		conf := testutil.Must(FromPreset(preset))(t)
+
+		if problems := Validate(&conf, &provided, nil); len(problems) > 0 {
+			t.Errorf("expected no problems in preset %s, got %v", preset, problems)
+		}
+	}
+}
+
+func TestFromPreset(t *testing.T) {
+	t.Parallel()
+
+	conf := testutil.Must(FromPreset("minimal"))(t)
+
+	if conf.Defaults.Global.Level != "ignore" {
+		t.Errorf("expected global default level ignore, got %q", conf.Defaults.Global.Level)
+	}
+
+	if conf.Defaults.Categories["bugs"].Level != levelError {
+		t.Errorf("expected default level error for bugs, got %q", conf.Defaults.Categories["bugs"].Level)
+	}
+
+	if origin := conf.Origins["rules.default.level"]; origin != "preset minimal" {
+		t.Errorf("expected rules.default.level set by preset minimal, got %q", origin)
+	}
+
+	if _, err := FromPreset("unknown"); err == nil || !strings.Contains(err.Error(), "unknown preset unknown") {
+		t.Errorf("expected unknown preset error, got %v", err)
+	}
+}
+
+func TestFromFileWithPreset(t *testing.T) {
+	t.Parallel()
+
+	fs := map[string]string{
+		".regal.yaml": `preset: minimal
+rules:
+  style:
+    line-length:
+      level: warning
+`,
+	}
+
+	test.WithTempFS(fs, func(root string) {
+		path := filepath.Join(root, ".regal.yaml")
+
+		conf := testutil.Must(FromPath(path))(t)
+
+		if conf.Defaults.Global.Level != "ignore" {
+			t.Errorf("expected global default level ignore from preset, got %q", conf.Defaults.Global.Level)
+		}
+
+		if conf.Rules["style"]["line-length"].Level != "warning" {
+			t.Errorf("expected line-length level warning, got %v", conf.Rules["style"]["line-length"])
+		}
+
+		if origin := conf.Origins["rules.style.line-length.level"]; origin != path {
+			t.Errorf("expected line-length level set by %s, got %q", path, origin)
+		}
+
+		file := testutil.Must(os.Open(path))(t)
+		defer file.Close()
+
+		// the preset passed replaces the one set in the file
+		conf = testutil.Must(FromFileWithPreset(file, "strict"))(t)
+
+		if conf.Defaults.Global.Level != "" {
+			t.Errorf("expected no global default level, got %q", conf.Defaults.Global.Level)
+		}
+
+		if !conf.Ignore.Directives.RequireReason {
+			t.Error("expected ignore directives to require a reason with strict preset")
+		}
+
+		if conf.Rules["style"]["line-length"].Level != "warning" {
+			t.Errorf("expected line-length level warning, got %v", conf.Rules["style"]["line-length"])
+		}
+	})
+}
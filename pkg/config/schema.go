@@ -0,0 +1,325 @@
+package config
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/open-policy-agent/regal/internal/docs"
+)
+
+const jsonSchemaDraft7 = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema describing Regal configuration, or some part of it. Only the keywords
+// needed to describe the configuration are supported.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	// Keywords holds any other keywords of the schema, like minimum or pattern, as provided in
+	// schemas declared by custom rules. These are kept as is, and considered when validating.
+	Keywords map[string]any `json:"-"`
+}
+
+// schemaKeywords are the keywords represented by the fields of Schema.
+var schemaKeywords = []string{
+	"$schema", "title", "description", "type", "enum", "default", "properties", "additionalProperties", "items", "anyOf",
+}
+
+// MarshalJSON marshals the schema along with any other keywords it holds.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+
+	bs, err := json.Marshal((*plain)(s))
+	if err != nil || len(s.Keywords) == 0 {
+		return bs, err //nolint:wrapcheck
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(bs, &values); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	for keyword, value := range s.Keywords {
+		if _, ok := values[keyword]; !ok {
+			values[keyword] = value
+		}
+	}
+
+	return json.Marshal(values) //nolint:wrapcheck
+}
+
+// UnmarshalJSON unmarshals the schema, keeping any keywords not represented by its fields in Keywords.
+func (s *Schema) UnmarshalJSON(bs []byte) error {
+	type plain Schema
+
+	if err := json.Unmarshal(bs, (*plain)(s)); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(bs, &values); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for _, keyword := range schemaKeywords {
+		delete(values, keyword)
+	}
+
+	if len(values) > 0 {
+		s.Keywords = values
+	}
+
+	return nil
+}
+
+// Lookup returns the schema found at the path of keys in configuration described by s, or nil if
+// no such schema exists. Items of lists are referenced by "-", as in "overrides", "-", "files".
+func (s *Schema) Lookup(path ...string) *Schema {
+	current := s
+
+	for _, key := range path {
+		var next *Schema
+
+		for _, candidate := range append([]*Schema{current}, current.AnyOf...) {
+			if key == "-" && candidate.Items != nil {
+				next = candidate.Items
+			} else if property, ok := candidate.Properties[key]; ok {
+				next = property
+			}
+
+			if next != nil {
+				break
+			}
+		}
+
+		if next == nil {
+			return nil
+		}
+
+		current = next
+	}
+
+	return current
+}
+
+// NewSchema creates a JSON Schema for user configuration. The provided configuration contains the
+// configuration of all rules built into Regal, from which the attributes of each rule, and their
+// types, are determined. The metadata of built-in and custom rules, keyed by category and title,
+// adds descriptions, and the schemas of any attributes not found in the provided configuration.
+// Custom rules may be configured with any attributes, unless they declare a schema of their own.
+func NewSchema(provided *Config, metadata map[string]map[string]RuleMetadata) *Schema {
+	rules := rulesSchema(provided, metadata)
+
+	return &Schema{
+		Schema:      jsonSchemaDraft7,
+		Title:       "Regal configuration",
+		Description: "Configuration of Regal, found in .regal/config.yaml or .regal.yaml",
+		Type:        "object",
+		Properties: map[string]*Schema{
+			"extends": {
+				Description: "Config file, or list of config files, to extend, merged in the order listed",
+				AnyOf:       []*Schema{{Type: "string"}, stringsSchema("")},
+			},
+			keyPreset: {
+				Description: "Preset provided by Regal to use as the base of this configuration",
+				Type:        "string",
+				Enum:        Presets(),
+			},
+			"rules": rules,
+			"overrides": {
+				Description: "Rule configuration applied only to files matching any of the patterns listed",
+				Type:        "array",
+				Items: objectSchema("", map[string]*Schema{
+					"files": stringsSchema("Glob patterns of the files the override applies to"),
+					"rules": rules,
+				}),
+			},
+			"ignore": objectSchema("Files and inline ignore directives to ignore", map[string]*Schema{
+				"files": stringsSchema("Glob patterns of files to exclude from all rules"),
+				"directives": objectSchema("How inline ignore directives are handled", map[string]*Schema{
+					"require-reason": {
+						Description: "Only honor ignore directives providing a reason",
+						Type:        "boolean",
+					},
+				}),
+			}),
+			"capabilities": capabilitiesSchema(),
+			"project": objectSchema("Project roots and Rego versions", map[string]*Schema{
+				"rego-version": regoVersionSchema("Rego version of the whole project"),
+				"roots": {
+					Description: "Roots of the project, either as paths or objects with a path",
+					Type:        "array",
+					Items: &Schema{AnyOf: []*Schema{
+						{Type: "string"},
+						objectSchema("", map[string]*Schema{
+							"path":         {Description: "Path of the root", Type: "string"},
+							"rego-version": regoVersionSchema("Rego version of the root"),
+						}),
+					}},
+				},
+			}),
+			"features": objectSchema("Optional features of Regal", map[string]*Schema{
+				"remote": objectSchema("Features using remote resources", map[string]*Schema{
+					"check-version": {
+						Description: "Check for new versions of Regal",
+						Type:        "boolean",
+					},
+				}),
+			}),
+		},
+		AdditionalProperties: false,
+	}
+}
+
+func rulesSchema(provided *Config, metadata map[string]map[string]RuleMetadata) *Schema {
+	categories := map[string]*Schema{"default": defaultSchema("Default level of all built-in rules")}
+
+	for category, rules := range provided.Rules {
+		properties := map[string]*Schema{
+			"default": defaultSchema("Default level of the built-in rules in the " + category + " category"),
+		}
+
+		for title, rule := range rules {
+			description := "Documentation: " + docs.CreateDocsURL(category, title)
+			if meta := metadata[category][title]; meta.Description != "" {
+				description = meta.Description + "\n\n" + description
+			}
+
+			properties[title] = ruleSchema(description, rule, metadata[category][title].Config)
+			properties[title].AdditionalProperties = false
+		}
+
+		categories[category] = objectSchema("Rules in the "+category+" category", properties)
+	}
+
+	for category, rules := range metadata {
+		if _, ok := categories[category]; !ok {
+			categories[category] = objectSchema("Custom rules in the "+category+" category", map[string]*Schema{})
+		}
+
+		for title, meta := range rules {
+			if _, ok := categories[category].Properties[title]; ok {
+				continue
+			}
+
+			schema := ruleSchema(meta.Description, Rule{}, nil)
+
+			// custom rules declaring a schema for their configuration may only be configured as declared
+			if declared := meta.ConfigSchema(); declared != nil {
+				maps.Copy(schema.Properties, declared.Properties)
+				schema.AdditionalProperties = declared.AdditionalProperties
+			}
+
+			categories[category].Properties[title] = schema
+		}
+	}
+
+	return objectSchema("Configuration of rules, by category", categories)
+}
+
+// ruleSchema returns the schema of a rule, with the attributes of the rule in the provided configuration,
+// typed by their default values, and any attributes declared in the metadata of the rule.
+func ruleSchema(description string, rule Rule, attributes map[string]*Schema) *Schema {
+	properties := map[string]*Schema{
+		keyLevel: levelSchema(),
+		"ignore": objectSchema("Files to ignore for this rule", map[string]*Schema{
+			"files": stringsSchema("Glob patterns of files to exclude from this rule"),
+		}),
+	}
+
+	for attribute, value := range rule.Extra {
+		properties[attribute] = valueSchema(value)
+	}
+
+	maps.Copy(properties, attributes)
+
+	return &Schema{Description: description, Type: "object", Properties: properties}
+}
+
+func defaultSchema(description string) *Schema {
+	return objectSchema(description, map[string]*Schema{keyLevel: levelSchema()})
+}
+
+func levelSchema() *Schema {
+	return &Schema{Description: "Level of violations reported by the rule", Type: "string", Enum: slices.Clone(levels)}
+}
+
+func regoVersionSchema(description string) *Schema {
+	return &Schema{Description: description, Type: "integer"}
+}
+
+func capabilitiesSchema() *Schema {
+	builtin := objectSchema("", map[string]*Schema{"name": {Description: "Name of the built-in function", Type: "string"}})
+	builtin.AdditionalProperties = nil
+
+	return objectSchema("Capabilities of the OPA version targeted", map[string]*Schema{
+		"from": objectSchema("Source of the capabilities", map[string]*Schema{
+			"engine": {Description: "Engine to load capabilities for, like opa", Type: "string"},
+			"version": {
+				Description: "Version of the engine, like v1.0.0, or range of versions, like >=0.60 <1.0",
+				Type:        "string",
+			},
+			"file":      {Description: "Path of a capabilities JSON file", Type: "string"},
+			"url":       {Description: "URL of a capabilities JSON file", Type: "string"},
+			"path":      {Description: "Path of an OPA binary to load capabilities from", Type: "string"},
+			"directory": {Description: "Path of a directory of capabilities JSON files", Type: "string"},
+		}),
+		"plus": objectSchema("Capabilities to add", map[string]*Schema{
+			"builtins": {Description: "Built-in functions to add", Type: "array", Items: builtin},
+		}),
+		"minus": objectSchema("Capabilities to remove", map[string]*Schema{
+			"builtins": {Description: "Built-in functions to remove", Type: "array", Items: builtin},
+		}),
+	})
+}
+
+func objectSchema(description string, properties map[string]*Schema) *Schema {
+	return &Schema{Description: description, Type: "object", Properties: properties, AdditionalProperties: false}
+}
+
+func stringsSchema(description string) *Schema {
+	return &Schema{Description: description, Type: "array", Items: &Schema{Type: "string"}}
+}
+
+// valueSchema returns a schema for values of the same type as the value provided by default.
+func valueSchema(value any) *Schema {
+	schema := &Schema{Default: value}
+
+	switch v := value.(type) {
+	case string:
+		schema.Type = "string"
+	case bool:
+		schema.Type = "boolean"
+	case int, int64, uint64:
+		schema.Type = "integer"
+	case float64:
+		schema.Type = "number"
+		if v == math.Trunc(v) {
+			schema.Type = "integer"
+		}
+	case []any:
+		schema.Type = "array"
+		if len(v) > 0 {
+			schema.Items = valueSchema(v[0])
+			schema.Items.Default = nil
+		}
+	case map[string]any:
+		schema.Type = "object"
+	}
+
+	return schema
+}
+
+// Keys returns the sorted keys of the properties of s.
+func (s *Schema) Keys() []string {
+	return slices.Sorted(maps.Keys(s.Properties))
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+
+	rbundle "github.com/open-policy-agent/regal/bundle"
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestNewSchema(t *testing.T) {
+	t.Parallel()
+
+	provided := testutil.Must(LoadConfigWithDefaultsFromBundle(rbundle.LoadedBundle(), nil))(t)
+
+	modules := make([]*ast.Module, 0, len(rbundle.LoadedBundle().Modules))
+	for _, module := range rbundle.LoadedBundle().Modules {
+		modules = append(modules, module.Parsed)
+	}
+
+	metadata := testutil.Must(RuleMetadataFromModules(modules...))(t)
+	schema := NewSchema(&provided, metadata)
+
+	lineLength := schema.Lookup("rules", "style", "line-length")
+	if lineLength == nil {
+		t.Fatal("expected schema for rule style/line-length")
+	}
+
+	if lineLength.Description == "" {
+		t.Error("expected rule description from metadata")
+	}
+
+	if s := lineLength.Properties["max-line-length"]; s == nil || s.Type != "integer" {
+		t.Errorf("expected max-line-length of type integer, got %+v", s)
+	}
+
+	if s := lineLength.Properties["non-breakable-word-threshold"]; s == nil || s.Type != "integer" {
+		t.Errorf("expected non-breakable-word-threshold declared in metadata, got %+v", s)
+	}
+
+	if s := schema.Lookup("overrides", "-", "rules", "bugs", "constant-condition", "level"); s == nil ||
+		!slices.Equal(s.Enum, levels) {
+		t.Errorf("expected level of rule in override to enumerate levels, got %+v", s)
+	}
+
+	if s := schema.Lookup("project", "roots", "-", "rego-version"); s == nil || s.Type != "integer" {
+		t.Errorf("expected rego-version of project root object, got %+v", s)
+	}
+
+	if s := schema.Lookup("rules", "style", "no-such-rule"); s != nil {
+		t.Errorf("expected no schema for unknown rule, got %+v", s)
+	}
+}
+
+func TestRuleMetadataFromModules(t *testing.T) {
+	t.Parallel()
+
+	module := ast.MustParseModuleWithOpts(`# METADATA
+# description: Custom rule
+# custom:
+#   config:
+#     max-things:
+#       type: integer
+package custom.regal.rules.acme.my_rule
+
+import data.regal.result
+`, ast.ParserOptions{ProcessAnnotation: true})
+
+	other := ast.MustParseModule("package acme.policy\n")
+
+	metadata := testutil.Must(RuleMetadataFromModules(module, other))(t)
+
+	if len(metadata) != 1 {
+		t.Fatalf("expected metadata for one category, got %v", metadata)
+	}
+
+	rule, ok := metadata["acme"]["my_rule"]
+	if !ok {
+		t.Fatalf("expected metadata for acme/my_rule, got %v", metadata)
+	}
+
+	if rule.Description != "Custom rule" {
+		t.Errorf("expected description 'Custom rule', got %q", rule.Description)
+	}
+
+	if s := rule.Config["max-things"]; s == nil || s.Type != "integer" {
+		t.Errorf("expected max-things of type integer, got %+v", s)
+	}
+}
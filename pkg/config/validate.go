@@ -0,0 +1,263 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+)
+
+// Problem describes a problem found in configuration, at the dot-separated path of the setting.
+type Problem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	return p.Path + ": " + p.Message
+}
+
+// the valid levels of a rule
+var levels = []string{"error", "warning", "ignore"}
+
+// Validate checks the rules configured in conf against the provided configuration, which contains
+// the configuration of all rules built into Regal, and the metadata of built-in and custom rules,
+// keyed by category and title. Unknown categories, rules and levels are reported, as are attributes
+// of built-in rules neither found in their provided configuration nor declared in their metadata.
+// Custom rules may be configured with any attributes, unless they declare a schema for their
+// configuration, in which case the configuration is validated against that.
+func Validate(conf, provided *Config, metadata map[string]map[string]RuleMetadata) []Problem {
+	var problems []Problem
+
+	if problem, ok := validateLevel("rules.default.level", conf.Defaults.Global.Level); !ok {
+		problems = append(problems, problem)
+	}
+
+	for _, category := range slices.Sorted(maps.Keys(conf.Defaults.Categories)) {
+		path := "rules." + category + ".default.level"
+
+		if problem, ok := validateLevel(path, conf.Defaults.Categories[category].Level); !ok {
+			problems = append(problems, problem)
+		}
+	}
+
+	problems = append(problems, validateRules("rules", conf.Rules, provided, metadata)...)
+
+	for i, override := range conf.Overrides {
+		path := fmt.Sprintf("overrides[%d]", i)
+
+		if len(override.Files) == 0 {
+			problems = append(problems, Problem{Path: path + ".files", Message: "override must list the files it applies to"})
+		}
+
+		problems = append(problems, validateRules(path+".rules", override.Rules, provided, metadata)...)
+	}
+
+	return problems
+}
+
+func validateRules(
+	prefix string,
+	rules map[string]Category,
+	provided *Config,
+	metadata map[string]map[string]RuleMetadata,
+) []Problem {
+	var problems []Problem
+
+	for _, category := range slices.Sorted(maps.Keys(rules)) {
+		_, builtinCategory := provided.Rules[category]
+		_, knownCategory := metadata[category]
+
+		if !builtinCategory && !knownCategory {
+			problems = append(problems, Problem{Path: prefix + "." + category, Message: "unknown category " + category})
+
+			continue
+		}
+
+		for _, title := range slices.Sorted(maps.Keys(rules[category])) {
+			path := prefix + "." + category + "." + title
+			rule := rules[category][title]
+
+			providedRule, builtin := provided.Rules[category][title]
+			meta, known := metadata[category][title]
+
+			if !builtin && !known {
+				problems = append(problems, Problem{Path: path, Message: unknownRuleMessage(category, title, provided)})
+
+				continue
+			}
+
+			if problem, ok := validateLevel(path+".level", rule.Level); !ok {
+				problems = append(problems, problem)
+			}
+
+			if !builtin {
+				problems = append(problems, validateRuleSchema(path, rule, meta.ConfigSchema())...)
+
+				continue
+			}
+
+			for _, attribute := range slices.Sorted(maps.Keys(rule.Extra)) {
+				_, declared := meta.Config[attribute]
+				if _, ok := providedRule.Extra[attribute]; !ok && !declared {
+					problems = append(problems, Problem{
+						Path:    path + "." + attribute,
+						Message: fmt.Sprintf("unknown attribute %s for rule %s", attribute, title),
+					})
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// ValidateRuleSchemas validates the configuration of rules in conf, including that of overrides, against
+// the schemas declared by the rules in the metadata provided, keyed by category and title. This is meant
+// for custom rules, as the configuration of built-in rules is validated against the provided configuration.
+func ValidateRuleSchemas(conf *Config, metadata map[string]map[string]RuleMetadata) []Problem {
+	problems := validateRuleSchemas("rules", conf.Rules, metadata)
+
+	for i, override := range conf.Overrides {
+		problems = append(problems, validateRuleSchemas(fmt.Sprintf("overrides[%d].rules", i), override.Rules, metadata)...)
+	}
+
+	return problems
+}
+
+func validateRuleSchemas(prefix string, rules map[string]Category, metadata map[string]map[string]RuleMetadata) []Problem {
+	var problems []Problem
+
+	for _, category := range slices.Sorted(maps.Keys(rules)) {
+		for _, title := range slices.Sorted(maps.Keys(rules[category])) {
+			if meta, ok := metadata[category][title]; ok {
+				path := prefix + "." + category + "." + title
+
+				problems = append(problems, validateRuleSchema(path, rules[category][title], meta.ConfigSchema())...)
+			}
+		}
+	}
+
+	return problems
+}
+
+// schemaError is an error reported by the json.match_schema built-in function.
+type schemaError struct {
+	Field string `json:"field"`
+	Type  string `json:"type"`
+	Desc  string `json:"desc"`
+}
+
+var (
+	matchSchemaQuery = sync.OnceValues(func() (rego.PreparedEvalQuery, error) {
+		return rego.New(
+			rego.Query("errors := json.match_schema(input.config, input.schema)[1]"),
+			rego.StrictBuiltinErrors(true),
+		).PrepareForEval(context.Background())
+	})
+
+	additionalProperty = regexp.MustCompile(`^Additional property (.+) is not allowed$`)
+)
+
+// validateRuleSchema validates the attributes a rule is configured with against the schema of its configuration.
+func validateRuleSchema(path string, rule Rule, schema *Schema) []Problem {
+	if schema == nil {
+		return nil
+	}
+
+	attributes := map[string]any(rule.Extra)
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+
+	errs, err := matchSchema(attributes, schema)
+	if err != nil {
+		return []Problem{{Path: path, Message: "invalid schema declared for configuration of rule: " + err.Error()}}
+	}
+
+	problems := make([]Problem, 0, len(errs))
+
+	for _, e := range errs {
+		problemPath := path
+
+		if e.Field != "(Root)" {
+			for segment := range strings.SplitSeq(e.Field, ".") {
+				if _, err := strconv.Atoi(segment); err == nil {
+					problemPath += "[" + segment + "]"
+				} else {
+					problemPath += "." + segment
+				}
+			}
+		}
+
+		if match := additionalProperty.FindStringSubmatch(e.Desc); match != nil {
+			problemPath += "." + match[1]
+		}
+
+		message := e.Desc
+		if message != "" {
+			message = strings.ToLower(message[:1]) + message[1:]
+		}
+
+		problems = append(problems, Problem{Path: problemPath, Message: message})
+	}
+
+	slices.SortStableFunc(problems, func(a, b Problem) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return problems
+}
+
+func matchSchema(value any, schema *Schema) ([]schemaError, error) {
+	query, err := matchSchemaQuery()
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare schema query: %w", err)
+	}
+
+	rs, err := query.Eval(context.Background(), rego.EvalInput(map[string]any{"config": value, "schema": schema}))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if len(rs) != 1 {
+		return nil, errors.New("expected exactly one result from schema query")
+	}
+
+	var errs []schemaError
+	if err := encoding.JSONRoundTrip(rs[0].Bindings["errors"], &errs); err != nil {
+		return nil, fmt.Errorf("failed to decode schema errors: %w", err)
+	}
+
+	return errs, nil
+}
+
+func validateLevel(path, level string) (Problem, bool) {
+	if level == "" || slices.Contains(levels, level) {
+		return Problem{}, true
+	}
+
+	return Problem{
+		Path:    path,
+		Message: fmt.Sprintf("invalid level %s, expected one of %s", level, strings.Join(levels, ", ")),
+	}, false
+}
+
+func unknownRuleMessage(category, title string, provided *Config) string {
+	for _, other := range slices.Sorted(maps.Keys(provided.Rules)) {
+		if _, ok := provided.Rules[other][title]; ok {
+			return fmt.Sprintf("unknown rule %s in category %s, the rule belongs to category %s", title, category, other)
+		}
+	}
+
+	return fmt.Sprintf("unknown rule %s in category %s", title, category)
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	provided := testutil.MustUnmarshalYAML[Config](t, []byte(`
+rules:
+  style:
+    line-length:
+      level: error
+      max-line-length: 120
+    todo-comment:
+      level: error
+  bugs:
+    constant-condition:
+      level: error
+`))
+
+	conf := testutil.MustUnmarshalYAML[Config](t, []byte(`
+rules:
+  default:
+    level: fatal
+  style:
+    line-length:
+      level: warn
+      max-line-lenght: 100
+      non-breakable-word-threshold: 80
+    constant-condition:
+      level: error
+  stlye:
+    todo-comment:
+      level: ignore
+  custom:
+    my-rule:
+      level: warning
+      anything: goes
+    strict-rule:
+      max-things: many
+      max_things: 1
+    unknown-rule:
+      level: error
+overrides:
+- rules:
+    style:
+      todo-comment:
+        level: ignore
+`))
+
+	metadata := map[string]map[string]RuleMetadata{
+		"style": {"line-length": {Config: map[string]*Schema{"non-breakable-word-threshold": {Type: "integer"}}}},
+		"custom": {
+			"my-rule":     {},
+			"strict-rule": {Config: map[string]*Schema{"max-things": {Type: "integer"}}},
+		},
+	}
+
+	problems := Validate(&conf, &provided, metadata)
+
+	actual := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		actual = append(actual, problem.String())
+	}
+
+	expected := []string{
+		"rules.default.level: invalid level fatal, expected one of error, warning, ignore",
+		"rules.custom.strict-rule.max-things: invalid type. Expected: integer, given: string",
+		"rules.custom.strict-rule.max_things: additional property max_things is not allowed",
+		"rules.custom.unknown-rule: unknown rule unknown-rule in category custom",
+		"rules.stlye: unknown category stlye",
+		"rules.style.constant-condition: unknown rule constant-condition in category style, " +
+			"the rule belongs to category bugs",
+		"rules.style.line-length.level: invalid level warn, expected one of error, warning, ignore",
+		"rules.style.line-length.max-line-lenght: unknown attribute max-line-lenght for rule line-length",
+		"overrides[0].files: override must list the files it applies to",
+	}
+
+	if !slices.Equal(actual, expected) {
+		t.Errorf("expected problems:\n%v\ngot:\n%v", expected, actual)
+	}
+}
@@ -0,0 +1,119 @@
+package fixer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// contextLines is the number of unchanged lines shown before and after the changed lines of a hunk.
+const contextLines = 3
+
+type diffLine struct {
+	op   diffmatchpatch.Operation
+	text string
+}
+
+// hunks returns the hunks of a line diff of the two texts before and after in the unified diff
+// format, without the file headers. If before and after are identical, an empty string is returned.
+func hunks(before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	dmp := diffmatchpatch.New()
+	runesBefore, runesAfter, lineArray := dmp.DiffLinesToRunes(before, after)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMainRunes(runesBefore, runesAfter, false), lineArray)
+
+	var all []diffLine
+
+	for _, d := range diffs {
+		for _, line := range lines(d.Text) {
+			all = append(all, diffLine{op: d.Type, text: line})
+		}
+	}
+
+	// the 0-based line numbers in before and after at which each line of the diff is found
+	rowBefore, rowAfter := make([]int, len(all)+1), make([]int, len(all)+1)
+
+	for i, line := range all {
+		rowBefore[i+1], rowAfter[i+1] = rowBefore[i], rowAfter[i]
+
+		if line.op != diffmatchpatch.DiffInsert {
+			rowBefore[i+1]++
+		}
+
+		if line.op != diffmatchpatch.DiffDelete {
+			rowAfter[i+1]++
+		}
+	}
+
+	var out strings.Builder
+
+	for start := 0; start < len(all); {
+		first := start
+		for first < len(all) && all[first].op == diffmatchpatch.DiffEqual {
+			first++
+		}
+
+		if first == len(all) {
+			break
+		}
+
+		// changes separated by no more unchanged lines than the context of two hunks share a hunk
+		last := first
+
+		for i := first + 1; i < len(all) && i-last-1 <= 2*contextLines; i++ {
+			if all[i].op != diffmatchpatch.DiffEqual {
+				last = i
+			}
+		}
+
+		from := max(first-contextLines, start)
+		to := min(last+contextLines+1, len(all))
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			hunkRange(rowBefore[from], rowBefore[to]), hunkRange(rowAfter[from], rowAfter[to]))
+
+		for _, line := range all[from:to] {
+			switch line.op {
+			case diffmatchpatch.DiffDelete:
+				out.WriteString("-")
+			case diffmatchpatch.DiffInsert:
+				out.WriteString("+")
+			case diffmatchpatch.DiffEqual:
+				out.WriteString(" ")
+			}
+
+			out.WriteString(line.text)
+		}
+
+		start = to
+	}
+
+	return out.String()
+}
+
+// hunkRange returns the range of lines from the 0-based from up to to in the format of a hunk
+// header, where an empty range is given by the line preceding it, or 0 at the start of a file.
+func hunkRange(from, to int) string {
+	if from == to {
+		return fmt.Sprintf("%d,0", from)
+	}
+
+	return fmt.Sprintf("%d,%d", from+1, to-from)
+}
+
+// lines splits text into lines, each including its newline. A last line without a newline is followed
+// by the same note on the missing newline as printed by git diff.
+func lines(text string) []string {
+	l := strings.SplitAfter(text, "\n")
+	if l[len(l)-1] == "" {
+		return l[:len(l)-1]
+	}
+
+	l[len(l)-1] += "\n\\ No newline at end of file\n"
+
+	return l
+}
@@ -0,0 +1,69 @@
+package fixer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHunks(t *testing.T) {
+	t.Parallel()
+
+	numbered := func(replace map[int]string) string {
+		var sb strings.Builder
+
+		for i := 1; i <= 20; i++ {
+			if line, ok := replace[i]; ok {
+				sb.WriteString(line)
+			} else {
+				fmt.Fprintf(&sb, "l%d\n", i)
+			}
+		}
+
+		return sb.String()
+	}
+
+	testCases := map[string]struct {
+		before   string
+		after    string
+		expected string
+	}{
+		"identical": {
+			before: "a\nb\n",
+			after:  "a\nb\n",
+		},
+		"changes sharing context": {
+			before:   numbered(nil),
+			after:    numbered(map[int]string{5: "x\n", 11: "y\n"}),
+			expected: "@@ -2,13 +2,13 @@\n l2\n l3\n l4\n-l5\n+x\n l6\n l7\n l8\n l9\n l10\n-l11\n+y\n l12\n l13\n l14\n",
+		},
+		"changes in separate hunks": {
+			before:   numbered(nil),
+			after:    numbered(map[int]string{2: "x\n", 15: ""}),
+			expected: "@@ -1,5 +1,5 @@\n l1\n-l2\n+x\n l3\n l4\n l5\n@@ -12,7 +12,6 @@\n l12\n l13\n l14\n-l15\n l16\n l17\n l18\n",
+		},
+		"added to empty file": {
+			after:    "a\nb\n",
+			expected: "@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		"removed all lines": {
+			before:   "a\nb\n",
+			expected: "@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		"newline added at end of file": {
+			before:   "a\nb",
+			after:    "a\nb\n",
+			expected: "@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := hunks(tc.before, tc.after); actual != tc.expected {
+				t.Errorf("expected hunks:\n%s\ngot:\n%s", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -98,11 +98,19 @@ func (f *Fixer) Fix(ctx context.Context, l *linter.Linter, fp fileprovider.FileP
 		return fixReport, nil
 	}
 
+	if err := fixReport.recordOriginalContents(fp); err != nil {
+		return nil, err
+	}
+
 	// Apply fixes that require linter violation triggers
 	if err := f.applyLinterFixes(ctx, l, fp, fixReport); err != nil {
 		return nil, err
 	}
 
+	if err := fixReport.recordFixedContents(fp); err != nil {
+		return nil, err
+	}
+
 	return fixReport, nil
 }
 
@@ -26,6 +26,9 @@ deny = true
 `,
 	}
 
+	// the file provider modifies the map provided when moving files
+	original := policies["/root/main/main.rego"]
+
 	memfp := fileprovider.NewInMemoryFileProvider(policies)
 
 	input, err := memfp.ToInput(map[string]ast.RegoVersion{
@@ -112,6 +115,16 @@ deny := true
 			t.Fatalf("unexpected fixes for %s:\ngot: %v\nexpected: %v", file, fixes, expectedFixes)
 		}
 	}
+
+	// check that the contents before and after fixing are found by the path after moving
+	before, after, ok := fixReport.ContentsForFile("/root/test/main.rego")
+	if !ok {
+		t.Fatal("expected contents to be reported for /root/test/main.rego")
+	}
+
+	if before != original || after != expectedFileContents["/root/test/main.rego"] {
+		t.Fatalf("unexpected contents reported:\nbefore:\n%s---\nafter:\n%s---", before, after)
+	}
 }
 
 func TestFixViolations(t *testing.T) {
@@ -190,3 +203,58 @@ func TestFixViolations(t *testing.T) {
 		}
 	}
 }
+
+func TestAttachFixes(t *testing.T) {
+	t.Parallel()
+
+	policy := "package test\n\nallow = true\n\n#no space\n"
+	memfp := fileprovider.NewInMemoryFileProvider(map[string]string{"/root/main/main.rego": policy})
+
+	violations := []report.Violation{
+		{Title: "use-assignment-operator", Location: report.Location{File: "/root/main/main.rego", Row: 3, Column: 7}},
+		{Title: "no-whitespace-comment", Location: report.Location{File: "/root/main/main.rego", Row: 5, Column: 1}},
+		{Title: "directory-package-mismatch", Location: report.Location{File: "/root/main/main.rego", Row: 1, Column: 9}},
+		{Title: "prefer-snake-case", Location: report.Location{File: "/root/main/main.rego", Row: 3, Column: 1}},
+	}
+
+	f := NewFixer().RegisterFixes(fixes.NewDefaultFixes()...).RegisterRoots("/root")
+
+	if err := f.AttachFixes(violations, memfp, &config.Config{}); err != nil {
+		t.Fatalf("failed to attach fixes: %v", err)
+	}
+
+	expected := []*report.Fix{
+		{
+			Description: "Apply the use-assignment-operator fix",
+			Edits: []report.TextEdit{{
+				Start:   report.Position{Row: 3, Column: 1},
+				End:     report.Position{Row: 4, Column: 1},
+				NewText: "allow := true\n",
+			}},
+		},
+		{
+			Description: "Apply the no-whitespace-comment fix",
+			Edits: []report.TextEdit{{
+				Start:   report.Position{Row: 5, Column: 1},
+				End:     report.Position{Row: 6, Column: 1},
+				NewText: "# no space\n",
+			}},
+		},
+		// renames can't be expressed as text edits
+		nil,
+		// no fix available
+		nil,
+	}
+
+	for i, violation := range violations { //nolint:gocritic
+		if (violation.Fix == nil) != (expected[i] == nil) ||
+			violation.Fix != nil && (violation.Fix.Description != expected[i].Description ||
+				!slices.Equal(violation.Fix.Edits, expected[i].Edits)) {
+			t.Errorf("expected fix %v for %s, got %v", expected[i], violation.Title, violation.Fix)
+		}
+	}
+
+	if contents, _ := memfp.Get("/root/main/main.rego"); contents != policy {
+		t.Errorf("expected file to be unchanged, got %q", contents)
+	}
+}
@@ -22,6 +22,7 @@ func NewDefaultFixes() []Fix {
 		&NoWhitespaceComment{},
 		&DirectoryPackageMismatch{},
 		&NonRawRegexPattern{},
+		&UnusedIgnoreDirective{},
 	}
 }
 
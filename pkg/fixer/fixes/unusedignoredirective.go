@@ -0,0 +1,117 @@
+package fixes
+
+import (
+	"cmp"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+const ignoreDirectivePrefix = "regal ignore:"
+
+var (
+	ignoreDirectiveRulesPattern = regexp.MustCompile(`^\s*([^\s,]+(?:,\s*[^\s,]+)*)`)
+	ignoreDirectiveRulePattern  = regexp.MustCompile(`^[^\s,]+`)
+	ignoreDirectiveSeparator    = regexp.MustCompile(`^,\s*`)
+)
+
+// UnusedIgnoreDirective removes rules from ignore directives that suppress no violations, or
+// the whole directive when no other rules remain. Locations are expected to span the rule name
+// in the directive, as reported by the unused-ignore-directive rule.
+type UnusedIgnoreDirective struct{}
+
+func (*UnusedIgnoreDirective) Name() string {
+	return "unused-ignore-directive"
+}
+
+func (u *UnusedIgnoreDirective) Fix(fc *FixCandidate, opts *RuntimeOptions) ([]FixResult, error) {
+	if opts == nil {
+		return nil, errors.New("missing runtime options")
+	}
+
+	original := strings.Split(fc.Contents, "\n")
+	lines := slices.Clone(original)
+	fixed := false
+
+	// fix from the end of the file, so that removing rules or lines doesn't move the locations not yet fixed
+	locations := slices.SortedFunc(slices.Values(opts.Locations), func(a, b report.Location) int {
+		return cmp.Or(cmp.Compare(b.Row, a.Row), cmp.Compare(b.Column, a.Column))
+	})
+
+	for _, loc := range locations {
+		if loc.Row < 1 || loc.Row > len(lines) {
+			continue
+		}
+
+		// the location is from before the file was changed, and no longer points at the directive
+		if loc.Text != nil && *loc.Text != original[loc.Row-1] {
+			continue
+		}
+
+		line, ok := removeIgnoredRule(lines[loc.Row-1], loc.Column-1)
+		if !ok {
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			lines = slices.Delete(lines, loc.Row-1, loc.Row)
+		} else {
+			lines[loc.Row-1] = line
+		}
+
+		fixed = true
+	}
+
+	if !fixed {
+		return nil, nil
+	}
+
+	return []FixResult{{
+		Title:    u.Name(),
+		Root:     opts.BaseDir,
+		Contents: strings.Join(lines, "\n"),
+	}}, nil
+}
+
+// removeIgnoredRule removes the rule starting at col from the ignore directive on the line, along
+// with the comma separating it from other rules. If it is the only rule, the directive is removed.
+func removeIgnoredRule(line string, col int) (string, bool) {
+	if col < 0 || col >= len(line) {
+		return line, false
+	}
+
+	prefix := strings.LastIndex(line[:col], ignoreDirectivePrefix)
+	if prefix == -1 {
+		return line, false
+	}
+
+	start := prefix + len(ignoreDirectivePrefix)
+
+	match := ignoreDirectiveRulesPattern.FindStringSubmatchIndex(line[start:])
+	if match == nil || col < start+match[2] || col >= start+match[3] {
+		return line, false
+	}
+
+	rules := line[start+match[2] : start+match[3]]
+	end := col + len(ignoreDirectiveRulePattern.FindString(line[col:]))
+
+	if !strings.Contains(rules, ",") {
+		// the only rule, so remove the whole directive, including any options following it
+		hash := strings.LastIndex(line[:prefix], "#")
+		if hash == -1 {
+			return line, false
+		}
+
+		return strings.TrimRight(line[:hash], " \t"), true
+	}
+
+	if separator := ignoreDirectiveSeparator.FindString(line[end:]); separator != "" {
+		return line[:col] + line[end+len(separator):], true
+	}
+
+	// the last rule, so remove the separator preceding it
+	return line[:strings.LastIndex(line[:col], ",")] + line[end:], true
+}
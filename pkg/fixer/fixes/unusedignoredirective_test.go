@@ -0,0 +1,99 @@
+package fixes
+
+import (
+	"testing"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+func TestUnusedIgnoreDirective(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		contents        string
+		locations       []report.Location
+		contentAfterFix string
+	}{
+		"only rule in directive on own line": {
+			contents:        "package test\n\n# regal ignore:foo\nallow := true\n",
+			locations:       []report.Location{{Row: 3, Column: 16}},
+			contentAfterFix: "package test\n\nallow := true\n",
+		},
+		"only rule in directive with options": {
+			contents:        "package test\n\n# regal ignore:foo until=2026-12-31 reason=\"legacy\"\nallow := true\n",
+			locations:       []report.Location{{Row: 3, Column: 16}},
+			contentAfterFix: "package test\n\nallow := true\n",
+		},
+		"only rule in directive following code": {
+			contents:        "package test\n\nallow := true # regal ignore:foo\n",
+			locations:       []report.Location{{Row: 3, Column: 30}},
+			contentAfterFix: "package test\n\nallow := true\n",
+		},
+		"only rule in directive following other comment": {
+			contents:        "package test\n\n# allow all # regal ignore:foo\nallow := true\n",
+			locations:       []report.Location{{Row: 3, Column: 28}},
+			contentAfterFix: "package test\n\n# allow all\nallow := true\n",
+		},
+		"first of several rules": {
+			contents:        "package test\n\n# regal ignore:foo, bar\nallow := true\n",
+			locations:       []report.Location{{Row: 3, Column: 16}},
+			contentAfterFix: "package test\n\n# regal ignore:bar\nallow := true\n",
+		},
+		"last of several rules": {
+			contents:        "package test\n\n# regal ignore:foo,bar reason=\"legacy\"\nallow := true\n",
+			locations:       []report.Location{{Row: 3, Column: 20}},
+			contentAfterFix: "package test\n\n# regal ignore:foo reason=\"legacy\"\nallow := true\n",
+		},
+		"all rules in several directives": {
+			contents: "package test\n\n# regal ignore:foo,bar\nallow := true\n\n# regal ignore:baz\ndeny := false\n",
+			locations: []report.Location{
+				{Row: 3, Column: 16},
+				{Row: 3, Column: 20},
+				{Row: 6, Column: 16},
+			},
+			contentAfterFix: "package test\n\nallow := true\n\ndeny := false\n",
+		},
+		"location not in directive": {
+			contents:  "package test\n\n# regal ignore:foo\nallow := true\n",
+			locations: []report.Location{{Row: 4, Column: 1}},
+		},
+		"location from before file changed": {
+			contents:  "package test\n\n# regal ignore:foo\nallow := true\n",
+			locations: []report.Location{{Row: 3, Column: 16, Text: stringPtr("# regal ignore:bar")}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fixResults, err := (&UnusedIgnoreDirective{}).Fix(
+				&FixCandidate{Filename: "test.rego", Contents: tc.contents},
+				&RuntimeOptions{Locations: tc.locations},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.contentAfterFix == "" {
+				if len(fixResults) != 0 {
+					t.Fatalf("expected no fix, got:\n%s", fixResults[0].Contents)
+				}
+
+				return
+			}
+
+			if len(fixResults) != 1 {
+				t.Fatalf("expected 1 fix result, got %d", len(fixResults))
+			}
+
+			if fixResults[0].Contents != tc.contentAfterFix {
+				t.Fatalf("expected:\n%s\ngot:\n%s", tc.contentAfterFix, fixResults[0].Contents)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -1,10 +1,12 @@
 package fixer
 
 import (
+	"fmt"
 	"slices"
 
 	"github.com/open-policy-agent/opa/v1/util"
 
+	"github.com/open-policy-agent/regal/pkg/fixer/fileprovider"
 	"github.com/open-policy-agent/regal/pkg/fixer/fixes"
 )
 
@@ -16,6 +18,10 @@ type Report struct {
 	conflictsManyToOne  map[string]map[string][]string
 	conflictsSourceFile map[string]map[string][]string
 	totalFixes          uint
+	// originalContents holds the contents of the files before fixing, keyed by their original path
+	originalContents map[string]string
+	// fixedContents holds the contents of the fixed files after fixing, keyed by their final path
+	fixedContents map[string]string
 }
 
 func NewReport() *Report {
@@ -24,6 +30,8 @@ func NewReport() *Report {
 		movedFiles:          make(map[string][]string),
 		conflictsManyToOne:  make(map[string]map[string][]string),
 		conflictsSourceFile: make(map[string]map[string][]string),
+		originalContents:    make(map[string]string),
+		fixedContents:       make(map[string]string),
 	}
 }
 
@@ -55,6 +63,41 @@ func (r *Report) OldPathForFile(newPath string) (string, bool) {
 	return oldPaths[0], true
 }
 
+// OriginalPathForFile returns the path the file had before fixing, following any number of moves.
+// The path provided is returned if the file was not moved.
+func (r *Report) OriginalPathForFile(newPath string) string {
+	path := newPath
+	seen := map[string]struct{}{path: {}}
+
+	for {
+		oldPath, ok := r.OldPathForFile(path)
+		if !ok {
+			return path
+		}
+
+		if _, ok := seen[oldPath]; ok {
+			return path
+		}
+
+		seen[oldPath] = struct{}{}
+		path = oldPath
+	}
+}
+
+// ContentsForFile returns the contents of a fixed file before and after fixing, where the file
+// is identified by its path after fixing. The contents are only known for reports returned by
+// Fixer.Fix, when there were no conflicts.
+func (r *Report) ContentsForFile(file string) (before string, after string, ok bool) {
+	after, ok = r.fixedContents[file]
+	if !ok {
+		return "", "", false
+	}
+
+	before, ok = r.originalContents[r.OriginalPathForFile(file)]
+
+	return before, after, ok
+}
+
 func (r *Report) FixedFiles() []string {
 	fixedFiles := util.Keys(r.fileFixes)
 
@@ -96,3 +139,41 @@ func (r *Report) RegisterConflictSourceFile(root, newPath, oldPath string) {
 func (r *Report) HasConflicts() bool {
 	return len(r.conflictsManyToOne) > 0 || len(r.conflictsSourceFile) > 0
 }
+
+// recordOriginalContents records the contents of all files of the file provider, before fixing.
+func (r *Report) recordOriginalContents(fp fileprovider.FileProvider) error {
+	files, err := fp.List()
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	for _, file := range files {
+		fc, err := fp.Get(file)
+		if err != nil {
+			return fmt.Errorf("failed to get file %s: %w", file, err)
+		}
+
+		r.originalContents[file] = fc
+	}
+
+	return nil
+}
+
+// recordFixedContents records the contents of the fixed files of the file provider, after fixing.
+// Nothing is recorded when there are conflicts, as the fixed files may then not be found.
+func (r *Report) recordFixedContents(fp fileprovider.FileProvider) error {
+	if r.HasConflicts() {
+		return nil
+	}
+
+	for _, file := range r.FixedFiles() {
+		fc, err := fp.Get(file)
+		if err != nil {
+			return fmt.Errorf("failed to get file %s: %w", file, err)
+		}
+
+		r.fixedContents[file] = fc
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package fixer
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/open-policy-agent/opa/v1/util"
 
+	"github.com/open-policy-agent/regal/internal/git"
+	rio "github.com/open-policy-agent/regal/internal/io"
 	"github.com/open-policy-agent/regal/pkg/fixer/fixes"
 )
 
@@ -23,6 +26,10 @@ func ReporterForFormat(format string, outputWriter io.Writer) (Reporter, error)
 	switch format {
 	case "pretty":
 		return NewPrettyReporter(outputWriter), nil
+	case "diff":
+		return NewDiffReporter(outputWriter), nil
+	case "json":
+		return NewJSONReporter(outputWriter), nil
 	default:
 		return nil, fmt.Errorf("unsupported format %s", format)
 	}
@@ -198,6 +205,232 @@ func (r *PrettyReporter) Report(fixReport *Report) error {
 	return nil
 }
 
+// DiffReporter outputs the changes of a fix report as a unified diff in the format of git diff, including
+// files moved, which may be applied using git apply. Like in git diff, paths are relative to the root of
+// the git repository of the current directory, or to the current directory when not in a git repository.
+type DiffReporter struct {
+	outputWriter io.Writer
+	baseDir      string
+}
+
+func NewDiffReporter(outputWriter io.Writer) *DiffReporter {
+	return &DiffReporter{
+		outputWriter: outputWriter,
+		baseDir:      diffBaseDir(),
+	}
+}
+
+// SetDryRun is a no-op, as the diff is the same whether the fixes were applied or not.
+func (*DiffReporter) SetDryRun(bool) {}
+
+func (r *DiffReporter) Report(fixReport *Report) error {
+	if fixReport.HasConflicts() {
+		var sb strings.Builder
+
+		if err := NewPrettyReporter(&sb).ReportConflicts(fixReport); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("no diff can be created for fixes with conflicts:\n%s", strings.TrimSpace(sb.String()))
+	}
+
+	for _, file := range fixReport.FixedFiles() {
+		diff, ok := fileDiff(fixReport, r.baseDir, file)
+		if !ok {
+			return fmt.Errorf("contents of fixed file %s not found in report", file)
+		}
+
+		fmt.Fprint(r.outputWriter, diff)
+	}
+
+	return nil
+}
+
+// JSONReporter outputs a fix report as JSON, with the fixes applied, and the resulting diff, per file.
+// Paths are relative to the same directory as those of the DiffReporter.
+type JSONReporter struct {
+	outputWriter io.Writer
+	baseDir      string
+	dryRun       bool
+}
+
+type jsonReport struct {
+	Files      []jsonFileReport `json:"files"`
+	Conflicts  []jsonConflict   `json:"conflicts,omitempty"`
+	TotalFixes uint             `json:"total_fixes"`
+	DryRun     bool             `json:"dry_run"`
+}
+
+type jsonFileReport struct {
+	File string `json:"file"`
+	// OldPath is the path of the file before fixing, if it was moved.
+	OldPath string   `json:"old_path,omitempty"`
+	Root    string   `json:"root"`
+	Fixes   []string `json:"fixes"`
+	// Diff is the unified diff of the changes to the file, as output by the diff format.
+	Diff string `json:"diff,omitempty"`
+}
+
+type jsonConflict struct {
+	// Kind is either "source-file", when an existing file would be overwritten,
+	// or "many-to-one", when multiple files would be moved to the same path.
+	Kind     string   `json:"kind"`
+	Root     string   `json:"root"`
+	File     string   `json:"file"`
+	OldPaths []string `json:"old_paths"`
+}
+
+func NewJSONReporter(outputWriter io.Writer) *JSONReporter {
+	return &JSONReporter{
+		outputWriter: outputWriter,
+		baseDir:      diffBaseDir(),
+	}
+}
+
+func (r *JSONReporter) SetDryRun(dryRun bool) {
+	r.dryRun = dryRun
+}
+
+func (r *JSONReporter) Report(fixReport *Report) error {
+	out := jsonReport{
+		Files:      make([]jsonFileReport, 0, len(fixReport.fileFixes)),
+		TotalFixes: fixReport.TotalFixes(),
+		DryRun:     r.dryRun,
+	}
+
+	for _, file := range fixReport.FixedFiles() {
+		fxs := fixReport.FixesForFile(file)
+		fileReport := jsonFileReport{
+			File:  relSlash(r.baseDir, file),
+			Fixes: make([]string, 0, len(fxs)),
+		}
+
+		if oldPath := fixReport.OriginalPathForFile(file); oldPath != file {
+			fileReport.OldPath = relSlash(r.baseDir, oldPath)
+		}
+
+		for _, fix := range fxs {
+			fileReport.Root = relSlash(r.baseDir, fix.Root)
+			fileReport.Fixes = append(fileReport.Fixes, fix.Title)
+		}
+
+		fileReport.Diff, _ = fileDiff(fixReport, r.baseDir, file)
+
+		out.Files = append(out.Files, fileReport)
+	}
+
+	out.Conflicts = append(
+		jsonConflicts("source-file", r.baseDir, fixReport.conflictsSourceFile, nil),
+		jsonConflicts("many-to-one", r.baseDir, fixReport.conflictsManyToOne, fixReport.movedFiles)...,
+	)
+
+	enc := json.NewEncoder(r.outputWriter)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("failed to encode fix report: %w", err)
+	}
+
+	return nil
+}
+
+// jsonConflicts returns the conflicts provided, keyed by root and path, sorted by both. For many to one
+// conflicts, the old paths are taken from the moved files, as these include all files moved, not just
+// the conflicting ones.
+func jsonConflicts(
+	kind, baseDir string,
+	conflicts map[string]map[string][]string,
+	movedFiles map[string][]string,
+) []jsonConflict {
+	result := make([]jsonConflict, 0)
+
+	roots := util.Keys(conflicts)
+	slices.Sort(roots)
+
+	for _, root := range roots {
+		files := util.Keys(conflicts[root])
+		slices.Sort(files)
+
+		for _, file := range files {
+			oldPaths := conflicts[root][file]
+			if movedFiles != nil {
+				oldPaths = movedFiles[file]
+			}
+
+			conflict := jsonConflict{
+				Kind:     kind,
+				Root:     relSlash(baseDir, root),
+				File:     relSlash(baseDir, file),
+				OldPaths: make([]string, 0, len(oldPaths)),
+			}
+
+			for _, oldPath := range oldPaths {
+				conflict.OldPaths = append(conflict.OldPaths, relSlash(baseDir, oldPath))
+			}
+
+			slices.Sort(conflict.OldPaths)
+
+			result = append(result, conflict)
+		}
+	}
+
+	return result
+}
+
+// fileDiff returns the changes to a fixed file as a unified diff in the format of git diff, with the paths
+// relative to the base directory. Files moved are reported as renames. False is returned if the contents
+// of the file are not known by the report.
+func fileDiff(fixReport *Report, baseDir, file string) (string, bool) {
+	before, after, ok := fixReport.ContentsForFile(file)
+	if !ok {
+		return "", false
+	}
+
+	oldPath := relSlash(baseDir, fixReport.OriginalPathForFile(file))
+	newPath := relSlash(baseDir, file)
+	body := hunks(before, after)
+
+	if body == "" && oldPath == newPath {
+		return "", true
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "diff --git a/%s b/%s\n", oldPath, newPath)
+
+	if oldPath != newPath {
+		fmt.Fprintf(&sb, "rename from %s\nrename to %s\n", oldPath, newPath)
+	}
+
+	if body != "" {
+		fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n%s", oldPath, newPath, body)
+	}
+
+	return sb.String(), true
+}
+
+// diffBaseDir returns the root of the git repository of the current directory, as git apply expects
+// paths relative to it, or the current directory when not in a git repository.
+func diffBaseDir() string {
+	wd := rio.Getwd()
+
+	if root, err := git.FindGitRepo(wd); err == nil && root != "" {
+		return root
+	}
+
+	return wd
+}
+
+// relSlash returns the path relative to the base directory, using forward slashes as in diffs,
+// or the path as is if no relative path can be determined.
+func relSlash(baseDir, path string) string {
+	if baseDir == "" || !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+
+	return filepath.ToSlash(relOrDefault(baseDir, path, path))
+}
+
 func relOrDefault(root, path, defaultValue string) string {
 	rel, err := filepath.Rel(root, path)
 	if err != nil {
@@ -155,3 +155,158 @@ Cannot move multiple files to: foo/policy1.rego
 		t.Fatalf("unexpected output:\nexpected:\n%s\ngot:\n%s", expected, got)
 	}
 }
+
+func reportWithContents() *Report {
+	report := NewReport()
+
+	// moved and formatted
+	report.AddFileFix("/workspace/bundle/policy.rego", fixes.FixResult{
+		Title: "directory-package-mismatch",
+		Root:  "/workspace/bundle",
+	})
+	report.MergeFixes("/workspace/bundle/foo/policy.rego", "/workspace/bundle/policy.rego")
+	report.RegisterOldPathForFile("/workspace/bundle/foo/policy.rego", "/workspace/bundle/policy.rego")
+	report.AddFileFix("/workspace/bundle/foo/policy.rego", fixes.FixResult{
+		Title: "opa-fmt",
+		Root:  "/workspace/bundle",
+	})
+
+	report.originalContents["/workspace/bundle/policy.rego"] = "package foo\n\nallow if {\n   input.x\n}\n"
+	report.fixedContents["/workspace/bundle/foo/policy.rego"] = "package foo\n\nallow if {\n\tinput.x\n}\n"
+
+	// moved only
+	report.AddFileFix("/workspace/bundle/bar.rego", fixes.FixResult{
+		Title: "directory-package-mismatch",
+		Root:  "/workspace/bundle",
+	})
+	report.MergeFixes("/workspace/bundle/bar/bar.rego", "/workspace/bundle/bar.rego")
+	report.RegisterOldPathForFile("/workspace/bundle/bar/bar.rego", "/workspace/bundle/bar.rego")
+
+	report.originalContents["/workspace/bundle/bar.rego"] = "package bar\n"
+	report.fixedContents["/workspace/bundle/bar/bar.rego"] = "package bar\n"
+
+	// formatted only, and without a trailing newline
+	report.AddFileFix("/workspace/bundle/baz/baz.rego", fixes.FixResult{
+		Title: "use-assignment-operator",
+		Root:  "/workspace/bundle",
+	})
+
+	report.originalContents["/workspace/bundle/baz/baz.rego"] = "package baz\n\nx = 1"
+	report.fixedContents["/workspace/bundle/baz/baz.rego"] = "package baz\n\nx := 1\n"
+
+	return report
+}
+
+func TestDiffReporterOutput(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	reporter := NewDiffReporter(&buffer)
+	reporter.baseDir = "/workspace"
+
+	if err := reporter.Report(reportWithContents()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `diff --git a/bundle/bar.rego b/bundle/bar/bar.rego
+rename from bundle/bar.rego
+rename to bundle/bar/bar.rego
+diff --git a/bundle/baz/baz.rego b/bundle/baz/baz.rego
+--- a/bundle/baz/baz.rego
++++ b/bundle/baz/baz.rego
+@@ -1,3 +1,3 @@
+ package baz
+ 
+-x = 1
+\ No newline at end of file
++x := 1
+diff --git a/bundle/policy.rego b/bundle/foo/policy.rego
+rename from bundle/policy.rego
+rename to bundle/foo/policy.rego
+--- a/bundle/policy.rego
++++ b/bundle/foo/policy.rego
+@@ -1,5 +1,5 @@
+ package foo
+ 
+ allow if {
+-   input.x
++	input.x
+ }
+`
+
+	if got := buffer.String(); got != expected {
+		t.Fatalf("unexpected output:\nexpected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestDiffReporterOutputWithConflicts(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	report := NewReport()
+	report.RegisterOldPathForFile("/workspace/bundle/foo.rego", "/workspace/bundle/bar.rego")
+	report.RegisterConflictSourceFile("/workspace/bundle", "/workspace/bundle/foo.rego", "/workspace/bundle/bar.rego")
+
+	if err := NewDiffReporter(&buffer).Report(report); err == nil {
+		t.Fatal("expected error for report with conflicts")
+	}
+
+	if buffer.Len() != 0 {
+		t.Fatalf("expected no output, got:\n%s", buffer.String())
+	}
+}
+
+func TestJSONReporterOutput(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	reporter := NewJSONReporter(&buffer)
+	reporter.baseDir = "/workspace"
+	reporter.SetDryRun(true)
+
+	if err := reporter.Report(reportWithContents()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{
+  "files": [
+    {
+      "file": "bundle/bar/bar.rego",
+      "old_path": "bundle/bar.rego",
+      "root": "bundle",
+      "fixes": [
+        "directory-package-mismatch"
+      ],
+      "diff": "diff --git a/bundle/bar.rego b/bundle/bar/bar.rego\nrename from bundle/bar.rego\nrename to bundle/bar/bar.rego\n"
+    },
+    {
+      "file": "bundle/baz/baz.rego",
+      "root": "bundle",
+      "fixes": [
+        "use-assignment-operator"
+      ],
+      "diff": "diff --git a/bundle/baz/baz.rego b/bundle/baz/baz.rego\n--- a/bundle/baz/baz.rego\n+++ b/bundle/baz/baz.rego\n@@ -1,3 +1,3 @@\n package baz\n \n-x = 1\n\\ No newline at end of file\n+x := 1\n"
+    },
+    {
+      "file": "bundle/foo/policy.rego",
+      "old_path": "bundle/policy.rego",
+      "root": "bundle",
+      "fixes": [
+        "directory-package-mismatch",
+        "opa-fmt"
+      ],
+      "diff": "diff --git a/bundle/policy.rego b/bundle/foo/policy.rego\nrename from bundle/policy.rego\nrename to bundle/foo/policy.rego\n--- a/bundle/policy.rego\n+++ b/bundle/foo/policy.rego\n@@ -1,5 +1,5 @@\n package foo\n \n allow if {\n-   input.x\n+\tinput.x\n }\n"
+    }
+  ],
+  "total_fixes": 4,
+  "dry_run": true
+}
+`
+
+	if got := buffer.String(); got != expected {
+		t.Fatalf("unexpected output:\nexpected:\n%s\ngot:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,90 @@
+package fixer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/config"
+	"github.com/open-policy-agent/regal/pkg/fixer/fileprovider"
+	"github.com/open-policy-agent/regal/pkg/fixer/fixes"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// AttachFixes attaches to each fixable violation the text edits its fix would make, without
+// modifying any files. Fixes that rename files rather than change their contents, like the fix
+// for directory-package-mismatch, can't be expressed as text edits, and are not attached.
+func (f *Fixer) AttachFixes(
+	violations []report.Violation,
+	fp fileprovider.FileProvider,
+	config *config.Config,
+) error {
+	for i := range violations {
+		fixInstance, ok := f.GetFixForName(violations[i].Title)
+		if !ok {
+			continue
+		}
+
+		file := violations[i].Location.File
+
+		contents, err := fp.Get(file)
+		if err != nil {
+			return fmt.Errorf("failed to get file %s: %w", file, err)
+		}
+
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path for %s: %w", file, err)
+		}
+
+		fixResults, err := fixInstance.Fix(&fixes.FixCandidate{Filename: file, Contents: contents}, &fixes.RuntimeOptions{
+			BaseDir:   util.FindClosestMatchingRoot(abs, f.registeredRoots),
+			Config:    config,
+			Locations: []report.Location{violations[i].Location},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fix %s: %w", file, err)
+		}
+
+		if len(fixResults) == 0 || fixResults[0].Rename != nil || fixResults[0].Contents == contents {
+			continue
+		}
+
+		violations[i].Fix = &report.Fix{
+			Description: fmt.Sprintf("Apply the %s fix", fixInstance.Name()),
+			Edits:       textEdits(contents, fixResults[0].Contents),
+		}
+	}
+
+	return nil
+}
+
+// textEdits returns the edit needed to turn before into after. Lines common to the beginning
+// and end of both are excluded, and the lines in between replaced as a whole, which keeps the
+// edit readable when presented as a suggested change.
+func textEdits(before, after string) []report.TextEdit {
+	if before == after {
+		return nil
+	}
+
+	beforeLines := strings.SplitAfter(before, "\n")
+	afterLines := strings.SplitAfter(after, "\n")
+
+	prefix := 0
+	for prefix < len(beforeLines) && prefix < len(afterLines) && beforeLines[prefix] == afterLines[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(beforeLines)-prefix && suffix < len(afterLines)-prefix &&
+		beforeLines[len(beforeLines)-1-suffix] == afterLines[len(afterLines)-1-suffix] {
+		suffix++
+	}
+
+	return []report.TextEdit{{
+		Start:   report.Position{Row: prefix + 1, Column: 1},
+		End:     report.Position{Row: len(beforeLines) - suffix + 1, Column: 1},
+		NewText: strings.Join(afterLines[prefix:len(afterLines)-suffix], ""),
+	}}
+}
@@ -0,0 +1,292 @@
+package linter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+
+	rbundle "github.com/open-policy-agent/regal/bundle"
+	"github.com/open-policy-agent/regal/pkg/config"
+	"github.com/open-policy-agent/regal/pkg/roast/encoding"
+	"github.com/open-policy-agent/regal/pkg/roast/rast"
+)
+
+// RuleExplanation explains whether a rule is enabled for a file, and why.
+type RuleExplanation struct {
+	Category string   `json:"category"`
+	Title    string   `json:"title"`
+	File     string   `json:"file,omitempty"`
+	Level    string   `json:"level"`
+	Reasons  []string `json:"reasons"`
+	Enabled  bool     `json:"enabled"`
+}
+
+// ValidateConfig checks the user configuration for unknown categories, rules, rule attributes and
+// invalid levels, considering both the rules built into Regal and any custom rules of the linter.
+func (l Linter) ValidateConfig() ([]config.Problem, error) {
+	if l.customRuleError != nil {
+		return nil, fmt.Errorf("failed to load custom rules: %w", l.customRuleError)
+	}
+
+	if l.userConfig == nil {
+		return nil, nil
+	}
+
+	provided, err := config.LoadConfigWithDefaultsFromBundle(rbundle.LoadedBundle(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provided config: %w", err)
+	}
+
+	metadata, err := l.ruleMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	return config.Validate(l.userConfig, &provided, metadata), nil
+}
+
+// ConfigSchema returns a JSON Schema for the user configuration, covering the rules built into
+// Regal, and any custom rules of the linter.
+func (l Linter) ConfigSchema() (*config.Schema, error) {
+	if l.customRuleError != nil {
+		return nil, fmt.Errorf("failed to load custom rules: %w", l.customRuleError)
+	}
+
+	provided, err := config.LoadConfigWithDefaultsFromBundle(rbundle.LoadedBundle(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provided config: %w", err)
+	}
+
+	metadata, err := l.ruleMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	return config.NewSchema(&provided, metadata), nil
+}
+
+// ExplainRule explains whether the rule is enabled for the file, and why, considering the same
+// configuration, command line options and notices as when linting. The file may be empty, in which
+// case only settings applying to all files are considered.
+func (l Linter) ExplainRule(ctx context.Context, title, file string) (RuleExplanation, error) {
+	if l.customRuleError != nil {
+		return RuleExplanation{}, fmt.Errorf("failed to load custom rules: %w", l.customRuleError)
+	}
+
+	provided, err := config.LoadConfigWithDefaultsFromBundle(rbundle.LoadedBundle(), nil)
+	if err != nil {
+		return RuleExplanation{}, fmt.Errorf("failed to read provided config: %w", err)
+	}
+
+	conf, err := l.GetConfig()
+	if err != nil {
+		return RuleExplanation{}, fmt.Errorf("failed to merge config: %w", err)
+	}
+
+	category := l.categoryOf(&provided, title)
+	if category == "" {
+		return RuleExplanation{}, fmt.Errorf("unknown rule %s", title)
+	}
+
+	query, err := ast.ParseBody(fmt.Sprintf(
+		"explanation := data.regal.config.explain(%s, %s)", ast.StringTerm(category), ast.StringTerm(title),
+	))
+	if err != nil {
+		return RuleExplanation{}, fmt.Errorf("failed to parse explain query: %w", err)
+	}
+
+	l.dataBundle = l.createDataBundle(*conf)
+
+	regoArgs, err := l.prepareRegoArgs(query)
+	if err != nil {
+		return RuleExplanation{}, fmt.Errorf("failed preparing explain query: %w", err)
+	}
+
+	input := map[string]any{}
+	if file != "" {
+		input["regal"] = map[string]any{"file": map[string]any{"name": file}}
+	}
+
+	rs, err := rego.New(append(regoArgs, rego.Input(input))...).Eval(ctx)
+	if err != nil {
+		return RuleExplanation{}, fmt.Errorf("failed evaluating explain query: %w", err)
+	}
+
+	if len(rs) != 1 {
+		return RuleExplanation{}, errors.New("expected exactly one result from explain query")
+	}
+
+	explanation := RuleExplanation{Category: category, Title: title, File: file}
+
+	if err := encoding.JSONRoundTrip(rs[0].Bindings["explanation"], &explanation); err != nil {
+		return RuleExplanation{}, fmt.Errorf("failed to decode explanation: %w", err)
+	}
+
+	explanation.Reasons = append([]string{l.levelReason(conf, &provided, category, title)}, explanation.Reasons...)
+
+	return explanation, nil
+}
+
+// ruleMetadata returns the metadata of the built-in rules and the custom rules of the linter,
+// keyed by category and title.
+func (l Linter) ruleMetadata() (map[string]map[string]config.RuleMetadata, error) {
+	modules := make([]*ast.Module, 0, len(rbundle.LoadedBundle().Modules))
+	for _, module := range rbundle.LoadedBundle().Modules {
+		modules = append(modules, module.Parsed)
+	}
+
+	metadata, err := config.RuleMetadataFromModules(modules...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule metadata: %w", err)
+	}
+
+	custom, err := l.customRuleMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	for category, rules := range custom {
+		if metadata[category] == nil {
+			metadata[category] = make(map[string]config.RuleMetadata)
+		}
+
+		maps.Copy(metadata[category], rules)
+	}
+
+	return metadata, nil
+}
+
+// customRuleMetadata returns the metadata of the custom rules of the linter, including any config
+// schemas provided next to the rules, keyed by category and title.
+func (l Linter) customRuleMetadata() (map[string]map[string]config.RuleMetadata, error) {
+	metadata, err := config.RuleMetadataFromModules(l.customRuleModules...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom rule metadata: %w", err)
+	}
+
+	for module, schema := range l.customRuleSchemas {
+		parts := rast.UnquotedPath(module.Package.Path)
+		// 1      2     3     4   5
+		// custom.regal.rules.cat.rule
+		if len(parts) != 5 {
+			continue
+		}
+
+		if meta, ok := metadata[parts[3]][parts[4]]; ok {
+			meta.Schema = schema
+			metadata[parts[3]][parts[4]] = meta
+		}
+	}
+
+	return metadata, nil
+}
+
+// validateCustomRuleConfig validates the user configuration of custom rules against any schemas
+// declared by the rules, with problems reported at the line of the config file where found.
+func (l Linter) validateCustomRuleConfig() error {
+	if l.userConfig == nil || len(l.customRuleModules) == 0 {
+		return nil
+	}
+
+	metadata, err := l.customRuleMetadata()
+	if err != nil {
+		return err
+	}
+
+	problems := config.ValidateRuleSchemas(l.userConfig, metadata)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(problems))
+
+	for _, problem := range problems {
+		switch file, line := l.userConfig.Location(problem.Path); {
+		case line > 0:
+			lines = append(lines, fmt.Sprintf("%s:%d: %s", file, line, problem))
+		case file != "":
+			lines = append(lines, fmt.Sprintf("%s: %s", file, problem))
+		default:
+			lines = append(lines, problem.String())
+		}
+	}
+
+	return fmt.Errorf("invalid configuration of custom rules:\n%s", strings.Join(lines, "\n"))
+}
+
+// customRules returns the titles of the custom rules of the linter, keyed by category.
+func (l Linter) customRules() map[string][]string {
+	rules := make(map[string][]string)
+
+	for _, module := range l.customRuleModules {
+		parts := rast.UnquotedPath(module.Package.Path)
+		// 1      2     3     4   5
+		// custom.regal.rules.cat.rule
+		if len(parts) != 5 {
+			continue
+		}
+
+		rules[parts[3]] = append(rules[parts[3]], parts[4])
+	}
+
+	return rules
+}
+
+// categoryOf returns the category of the rule, either built-in as found in the provided config, or custom.
+func (l Linter) categoryOf(provided *config.Config, title string) string {
+	for category, rules := range provided.Rules {
+		if _, ok := rules[title]; ok {
+			return category
+		}
+	}
+
+	for category, titles := range l.customRules() {
+		if slices.Contains(titles, title) {
+			return category
+		}
+	}
+
+	return ""
+}
+
+// levelReason describes where the level configured for a rule comes from, before any overrides or
+// command line flags are considered.
+func (l Linter) levelReason(conf, provided *config.Config, category, title string) string {
+	// category and global defaults only apply to built-in rules
+	_, builtin := provided.Rules[category][title]
+
+	if uc := l.userConfig; uc != nil {
+		if level := uc.Rules[category][title].Level; level != "" {
+			return fmt.Sprintf("level %s set in %s", level, origin(uc, "rules."+category+"."+title+".level"))
+		}
+
+		if level := uc.Defaults.Categories[category].Level; level != "" && builtin {
+			return fmt.Sprintf("default level %s for category %s set in %s",
+				level, category, origin(uc, "rules."+category+".default.level"))
+		}
+
+		if level := uc.Defaults.Global.Level; level != "" && builtin {
+			return fmt.Sprintf("default level %s for all rules set in %s", level, origin(uc, "rules.default.level"))
+		}
+	}
+
+	if level := conf.Rules[category][title].Level; level != "" {
+		return fmt.Sprintf("level %s set in provided configuration", level)
+	}
+
+	return "no level configured, defaulting to error"
+}
+
+func origin(conf *config.Config, key string) string {
+	if file, ok := conf.Origins[key]; ok {
+		return file
+	}
+
+	return "user configuration"
+}
@@ -0,0 +1,81 @@
+package linter
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/open-policy-agent/regal/internal/testutil"
+	"github.com/open-policy-agent/regal/pkg/config"
+)
+
+func TestExplainRule(t *testing.T) {
+	t.Parallel()
+
+	userConfig := config.Config{
+		Rules: map[string]config.Category{
+			"style": {"line-length": config.Rule{Level: "warning"}},
+		},
+		Overrides: []config.Override{{
+			Files: []string{"generated/**"},
+			Rules: map[string]config.Category{
+				"style": {"line-length": config.Rule{Level: "ignore"}},
+			},
+		}},
+	}
+
+	l := NewLinter().WithUserConfig(userConfig)
+
+	cases := []struct {
+		file    string
+		level   string
+		reasons []string
+		enabled bool
+	}{
+		{
+			file:    "policy/p.rego",
+			level:   "warning",
+			reasons: []string{"level warning set in user configuration"},
+			enabled: true,
+		},
+		{
+			file:  "generated/p.rego",
+			level: "ignore",
+			reasons: []string{
+				"level warning set in user configuration",
+				"level set to ignore by override for files matching generated/**",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+
+			explanation := testutil.Must(l.ExplainRule(t.Context(), "line-length", tc.file))(t)
+
+			if explanation.Category != "style" {
+				t.Errorf("expected category style, got %s", explanation.Category)
+			}
+
+			if explanation.Enabled != tc.enabled {
+				t.Errorf("expected enabled to be %t, got %t", tc.enabled, explanation.Enabled)
+			}
+
+			if explanation.Level != tc.level {
+				t.Errorf("expected level %s, got %s", tc.level, explanation.Level)
+			}
+
+			if !slices.Equal(explanation.Reasons, tc.reasons) {
+				t.Errorf("expected reasons:\n%v\ngot:\n%v", tc.reasons, explanation.Reasons)
+			}
+		})
+	}
+}
+
+func TestExplainRuleUnknownRule(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewLinter().ExplainRule(t.Context(), "no-such-rule", ""); err == nil {
+		t.Fatal("expected error for unknown rule")
+	}
+}
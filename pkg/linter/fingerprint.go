@@ -0,0 +1,49 @@
+package linter
+
+import (
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// setFingerprints sets the fingerprint of each violation, using the provided modules to determine
+// the name of the rule enclosing each violation, when the module of the violation is available.
+// Provide no modules to leave the enclosing rule out of the fingerprints.
+func setFingerprints(violations []report.Violation, modules map[string]*ast.Module) {
+	occurrences := make(map[string]int, len(violations))
+
+	for i := range violations {
+		enclosing := enclosingRuleName(modules[violations[i].Location.File], violations[i].Location.Row)
+		fingerprint := report.Fingerprint(violations[i], enclosing, 0)
+
+		if n := occurrences[fingerprint]; n > 0 {
+			violations[i].Fingerprint = report.Fingerprint(violations[i], enclosing, n)
+		} else {
+			violations[i].Fingerprint = fingerprint
+		}
+
+		occurrences[fingerprint]++
+	}
+}
+
+// enclosingRuleName returns the name of the rule spanning the provided row, or an empty
+// string if the row is outside of any rule, e.g. in the package declaration or imports.
+func enclosingRuleName(module *ast.Module, row int) string {
+	if module == nil || row == 0 {
+		return ""
+	}
+
+	for _, rule := range module.Rules {
+		if rule.Location == nil || row < rule.Location.Row {
+			continue
+		}
+
+		if row <= rule.Location.Row+strings.Count(string(rule.Location.Text), "\n") {
+			return rule.Head.Ref().String()
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,186 @@
+package linter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+const (
+	ignoreDirectivePrefix = "regal ignore:"
+
+	// the rule reporting unused ignore directives, see filterIgnored
+	unusedIgnoreDirectiveTitle = "unused-ignore-directive"
+)
+
+// ignoreDirectiveUse identifies a rule named in an ignore directive.
+type ignoreDirectiveUse struct {
+	file string
+	row  int
+	rule string
+}
+
+var (
+	// the rules of a directive are separated by commas, optionally followed by whitespace
+	ignoreDirectiveRulesPattern = regexp.MustCompile(`^\s*([^\s,]+(?:,\s*[^\s,]+)*)`)
+	// options are key=value pairs, where the value may be quoted to allow whitespace
+	ignoreDirectiveOptionPattern = regexp.MustCompile(`(\w+)=(?:"([^"]*)"|(\S+))`)
+)
+
+// ignoreDirectives returns the ignore directives found in the comments of the module.
+func ignoreDirectives(name string, module *ast.Module) []report.IgnoreDirective {
+	if module == nil {
+		return nil
+	}
+
+	var directives []report.IgnoreDirective
+
+	for _, comment := range module.Comments {
+		directive, ok := parseIgnoreDirective(string(comment.Text))
+		if !ok {
+			continue
+		}
+
+		text := string(comment.Location.Text)
+
+		directive.Location = report.Location{
+			File:   name,
+			Row:    comment.Location.Row,
+			Column: comment.Location.Col,
+			Text:   &text,
+			End:    &report.Position{Row: comment.Location.Row, Column: comment.Location.Col + len(text)},
+		}
+
+		directives = append(directives, directive)
+	}
+
+	return directives
+}
+
+// parseIgnoreDirective parses the text of a comment containing an ignore directive, like:
+//
+//	regal ignore:line-length,prefer-snake-case until=2026-12-31 reason="legacy"
+//
+// The directive may be preceded by other text, as in `# some comment # regal ignore:rule`.
+func parseIgnoreDirective(text string) (report.IgnoreDirective, bool) {
+	_, after, found := strings.Cut(text, ignoreDirectivePrefix)
+	if !found {
+		return report.IgnoreDirective{}, false
+	}
+
+	match := ignoreDirectiveRulesPattern.FindStringSubmatch(after)
+	if match == nil {
+		return report.IgnoreDirective{}, false
+	}
+
+	directive := report.IgnoreDirective{}
+
+	for rule := range strings.SplitSeq(match[1], ",") {
+		directive.Rules = append(directive.Rules, strings.TrimSpace(rule))
+	}
+
+	for _, option := range ignoreDirectiveOptionPattern.FindAllStringSubmatch(after[len(match[0]):], -1) {
+		value := option[2] + option[3]
+
+		switch option[1] {
+		case "until":
+			directive.Until = value
+		case "reason":
+			directive.Reason = value
+		}
+	}
+
+	return directive, true
+}
+
+// ignoreDirectiveProblem returns a description of why the directive should not be honored,
+// or an empty string if it should.
+func ignoreDirectiveProblem(directive report.IgnoreDirective, requireReason bool, now time.Time) string {
+	if directive.Until != "" {
+		if _, err := time.Parse(time.DateOnly, directive.Until); err != nil {
+			return fmt.Sprintf("ignore directive has invalid until date %q, expected YYYY-MM-DD", directive.Until)
+		}
+
+		// dates in this format compare correctly as strings
+		if now.Format(time.DateOnly) > directive.Until {
+			return "ignore directive expired on " + directive.Until
+		}
+	}
+
+	if requireReason && directive.Reason == "" {
+		return "ignore directive provides no reason, which is required by configuration"
+	}
+
+	return ""
+}
+
+// filterIgnored returns the violations not suppressed by any of the ignore directives, keyed by
+// file name. Directives apply to violations on the same line, and the line following them. When
+// all directives matching a violation are expired or otherwise not honored, the violation is kept,
+// with the directives included as related locations to explain why.
+//
+// The unused-ignore-directive rule reports all rules named in directives that it can't rule out
+// as unused. Since only the linter knows which violations were suppressed, this is where the
+// violations for directives that turned out to match a violation are removed.
+func filterIgnored(
+	violations []report.Violation,
+	directives map[string][]report.IgnoreDirective,
+	requireReason bool,
+	now time.Time,
+) []report.Violation {
+	if len(directives) == 0 {
+		return violations
+	}
+
+	filtered := make([]report.Violation, 0, len(violations))
+	used := make(map[ignoreDirectiveUse]bool)
+
+violations:
+	for i := range violations {
+		var problems []report.RelatedLocation
+
+		for _, directive := range directives[violations[i].Location.File] { //nolint:gocritic
+			row := violations[i].Location.Row
+			if (directive.Location.Row != row && directive.Location.Row != row-1) ||
+				!slices.Contains(directive.Rules, violations[i].Title) {
+				continue
+			}
+
+			used[ignoreDirectiveUse{violations[i].Location.File, directive.Location.Row, violations[i].Title}] = true
+
+			problem := ignoreDirectiveProblem(directive, requireReason, now)
+			if problem == "" {
+				continue violations
+			}
+
+			problems = append(problems, report.RelatedLocation{Message: problem, Location: directive.Location})
+		}
+
+		violations[i].RelatedLocations = append(violations[i].RelatedLocations, problems...)
+
+		filtered = append(filtered, violations[i])
+	}
+
+	return slices.DeleteFunc(filtered, func(violation report.Violation) bool {
+		return violation.Title == unusedIgnoreDirectiveTitle && used[ignoreDirectiveUse{
+			violation.Location.File, violation.Location.Row, ruleNamedAt(violation.Location),
+		}]
+	})
+}
+
+// ruleNamedAt returns the name of the rule at the location of an unused-ignore-directive violation,
+// which spans the rule name in the directive.
+func ruleNamedAt(location report.Location) string {
+	if location.Text == nil || location.End == nil ||
+		location.Column < 1 || location.End.Column-1 > len(*location.Text) || location.Column >= location.End.Column {
+		return ""
+	}
+
+	return (*location.Text)[location.Column-1 : location.End.Column-1]
+}
@@ -2,6 +2,7 @@ package linter
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -13,6 +14,7 @@ import (
 	"strings"
 	"sync"
 	"testing/fstest"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -26,6 +28,7 @@ import (
 	outil "github.com/open-policy-agent/opa/v1/util"
 
 	rbundle "github.com/open-policy-agent/regal/bundle"
+	"github.com/open-policy-agent/regal/internal/cache"
 	rio "github.com/open-policy-agent/regal/internal/io"
 	regalmetrics "github.com/open-policy-agent/regal/internal/metrics"
 	"github.com/open-policy-agent/regal/internal/util"
@@ -57,7 +60,9 @@ type Linter struct {
 	enableCategory       []string
 	ignoreFiles          []string
 	customRuleModules    []*ast.Module
+	customRuleSchemas    map[*ast.Module]*config.Schema
 	overriddenAggregates map[string][]report.Aggregate
+	overriddenDirectives map[string][]report.IgnoreDirective
 	useCollectQuery      bool
 	debugMode            bool
 	exportAggregates     bool
@@ -65,12 +70,20 @@ type Linter struct {
 	enableAll            bool
 	profiling            bool
 	instrumentation      bool
+	concurrency          int
+	shardIndex           int
+	shardTotal           int
 	hasCustomRules       bool
 	isPrepared           bool
 
 	preparedQuery *rego.PreparedEvalQuery
 }
 
+// FileViolationsFunc is called with the violations found in a single file, as soon as all
+// non-aggregate rules have been evaluated for that file. Calls are serialized, so implementations
+// need not be safe for concurrent use. Returning an error cancels the linting run.
+type FileViolationsFunc func(file string, violations []report.Violation) error
+
 var (
 	lintQueryStr         = "lint := data.regal.main.lint"
 	enabledRulesQueryStr = `[rule |
@@ -150,9 +163,13 @@ func (l Linter) WithCustomRules(paths []string) Linter {
 				return l
 			}
 
-			l = l.WithCustomRulesFromFS(fstest.MapFS{
-				filepath.Base(path): &fstest.MapFile{Data: contents},
-			}, ".")
+			fsys := fstest.MapFS{filepath.Base(path): &fstest.MapFile{Data: contents}}
+
+			if schema, err := os.ReadFile(schemaFileOf(path)); err == nil {
+				fsys[filepath.Base(schemaFileOf(path))] = &fstest.MapFile{Data: schema}
+			}
+
+			l = l.WithCustomRulesFromFS(fsys, ".")
 		}
 	}
 
@@ -163,6 +180,8 @@ func (l Linter) WithCustomRules(paths []string) Linter {
 
 // WithCustomRulesFromFS adds custom rules for evaluation from a filesystem implementing the fs.FS interface.
 // A root path within the filesystem must also be specified. Note, _test.rego files will be ignored.
+// A JSON schema for the configuration of a rule may be provided in a file next to it, named like the
+// rule file but with a .schema.json extension, e.g. my_rule.schema.json for my_rule.rego.
 func (l Linter) WithCustomRulesFromFS(f fs.FS, rootPath string) Linter {
 	if f == nil {
 		return l
@@ -178,13 +197,43 @@ func (l Linter) WithCustomRulesFromFS(f fs.FS, rootPath string) Linter {
 		return l
 	}
 
-	for _, m := range modules {
+	l.customRuleSchemas = maps.Clone(l.customRuleSchemas)
+	if l.customRuleSchemas == nil {
+		l.customRuleSchemas = make(map[*ast.Module]*config.Schema)
+	}
+
+	for path, m := range modules {
 		l.customRuleModules = append(l.customRuleModules, m)
+
+		bs, err := fs.ReadFile(f, schemaFileOf(path))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			l.customRuleError = fmt.Errorf("failed to read config schema of custom rule %s: %w", path, err)
+
+			return l
+		}
+
+		var schema config.Schema
+		if err := json.Unmarshal(bs, &schema); err != nil {
+			l.customRuleError = fmt.Errorf("failed to decode config schema of custom rule %s: %w", path, err)
+
+			return l
+		}
+
+		l.customRuleSchemas[m] = &schema
 	}
 
 	return l
 }
 
+// schemaFileOf returns the path of the file declaring the config schema of the custom rule at path.
+func schemaFileOf(path string) string {
+	return strings.TrimSuffix(path, ".rego") + ".schema.json"
+}
+
 // WithDebugMode enables debug mode.
 func (l Linter) WithDebugMode(debugMode bool) Linter {
 	l.debugMode = debugMode
@@ -319,6 +368,38 @@ func (l Linter) WithAggregates(aggregates map[string][]report.Aggregate) Linter
 	return l
 }
 
+// WithConcurrency sets the number of workers to shard input files across when linting. Each
+// worker has its own prepared query, and its own base cache if one was provided with
+// WithBaseCache. The default of 0 lints all files concurrently using a single prepared query.
+// Regardless of concurrency, the violations of the final report are sorted deterministically.
+func (l Linter) WithConcurrency(concurrency int) Linter {
+	l.concurrency = concurrency
+
+	return l
+}
+
+// WithIgnoreDirectives supplies the ignore directives of the files that aggregates provided
+// via WithAggregates were collected from, so that these directives are respected by the
+// aggregate rules. Likely exported in a previous run, along with the aggregates.
+func (l Linter) WithIgnoreDirectives(directives map[string][]report.IgnoreDirective) Linter {
+	l.overriddenDirectives = directives
+
+	return l
+}
+
+// WithShard limits linting to a single shard of the input files, where index is the 1-based
+// index of the shard, and total the number of shards. Files are assigned to shards based on
+// their sorted order, meaning that the shards of runs provided the same input are disjoint.
+// Since aggregate rules require data from all files, these are not evaluated when sharding.
+// Instead, aggregates should be exported from each shard (see WithExportAggregates), and
+// later provided to a single run of the aggregate rules using WithAggregates.
+func (l Linter) WithShard(index, total int) Linter {
+	l.shardIndex = index
+	l.shardTotal = total
+
+	return l
+}
+
 // WithBaseCache sets the base cache (cache for "JSON" documents) to use for evaluation.
 // This feature is **experimental** and should not be relied on by external clients for
 // the time being.
@@ -369,6 +450,22 @@ func (l Linter) MustPrepare(ctx context.Context) Linter {
 
 // Lint runs the linter on provided policies.
 func (l Linter) Lint(ctx context.Context) (report.Report, error) {
+	return l.lintInput(ctx, nil)
+}
+
+// LintStream runs the linter on provided policies, calling onFile with the violations of each
+// file as soon as that file has been linted by all non-aggregate rules. The returned report is
+// identical to that of Lint, and includes the violations already delivered to onFile, as well
+// as those reported by aggregate rules, which are only known once all files have been processed.
+func (l Linter) LintStream(ctx context.Context, onFile FileViolationsFunc) (report.Report, error) {
+	if onFile == nil {
+		return report.Report{}, errors.New("no callback provided for streaming results")
+	}
+
+	return l.lintInput(ctx, onFile)
+}
+
+func (l Linter) lintInput(ctx context.Context, onFile FileViolationsFunc) (report.Report, error) {
 	l.startTimer(regalmetrics.RegalLint)
 
 	finalReport := report.Report{}
@@ -395,6 +492,8 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 		return report.Report{}, fmt.Errorf("errors encountered when reading files to lint: %w", err)
 	}
 
+	filtered = l.shard(filtered)
+
 	l.stopTimer(regalmetrics.RegalFilterIgnoredFiles)
 	l.startTimer(regalmetrics.RegalInputParse)
 
@@ -429,7 +528,7 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 			return report.Report{}, fmt.Errorf("failed to filter paths: %w", err)
 		}
 
-		for _, filename := range filteredPaths {
+		for _, filename := range l.shard(filteredPaths) {
 			input.FileNames = append(input.FileNames, filename)
 			input.Modules[filename] = l.inputModules.Modules[filename]
 			input.FileContent[filename] = l.inputModules.FileContent[filename]
@@ -438,7 +537,7 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 		l.stopTimer(regalmetrics.RegalFilterIgnoredModules)
 	}
 
-	regoReport, err := l.lint(ctx, input)
+	regoReport, err := l.lint(ctx, input, onFile)
 	if err != nil {
 		return report.Report{}, fmt.Errorf("failed to lint using Rego rules: %w", err)
 	}
@@ -463,18 +562,34 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 		for k, aggregates := range l.overriddenAggregates {
 			allAggregates[k] = append(allAggregates[k], aggregates...)
 		}
-	} else if len(input.FileNames) > 1 {
+	} else if len(input.FileNames) > 1 && !l.isSharded() {
 		for k, aggregates := range regoReport.Aggregates {
 			allAggregates[k] = append(allAggregates[k], aggregates...)
 		}
 	}
 
 	if len(allAggregates) > 0 {
-		aggregateReport, err := l.lintWithAggregateRules(ctx, allAggregates, regoReport.IgnoreDirectives)
+		ignoreDirectives := regoReport.IgnoreDirectives
+		if len(l.overriddenDirectives) > 0 {
+			ignoreDirectives = make(map[string][]report.IgnoreDirective, len(l.overriddenDirectives)+len(ignoreDirectives))
+			maps.Copy(ignoreDirectives, l.overriddenDirectives)
+			maps.Copy(ignoreDirectives, regoReport.IgnoreDirectives)
+		}
+
+		aggregateReport, err := l.lintWithAggregateRules(ctx, allAggregates)
 		if err != nil {
 			return report.Report{}, fmt.Errorf("failed to lint using Rego aggregate rules: %w", err)
 		}
 
+		aggregateReport.Violations = filterIgnored(
+			aggregateReport.Violations, ignoreDirectives, l.ignoreDirectivesRequireReason(), time.Now(),
+		)
+
+		// the modules of other shards aren't available when linting using merged aggregates, so the
+		// enclosing rule is left out of the fingerprints of aggregate violations, which would otherwise
+		// differ from those of violations reported when linting without shards
+		setFingerprints(aggregateReport.Violations, nil)
+
 		finalReport.Violations = append(finalReport.Violations, aggregateReport.Violations...)
 
 		if l.profiling {
@@ -482,6 +597,8 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 		}
 	}
 
+	report.SortViolations(finalReport.Violations)
+
 	finalReport.Summary = report.Summary{
 		FilesScanned:  len(input.FileNames),
 		FilesFailed:   len(finalReport.ViolationsFileCount()),
@@ -496,6 +613,11 @@ func (l Linter) Lint(ctx context.Context) (report.Report, error) {
 		}
 	}
 
+	// also needed for aggregate rules to respect ignore directives when evaluated in a later run
+	if len(regoReport.IgnoreDirectives) > 0 {
+		finalReport.IgnoreDirectives = regoReport.IgnoreDirectives
+	}
+
 	if l.metrics != nil {
 		l.metrics.Timer(regalmetrics.RegalLint).Stop()
 
@@ -604,7 +726,7 @@ func (l Linter) prepareQuery(ctx context.Context) (*rego.PreparedEvalQuery, erro
 }
 
 func (l Linter) validate(conf *config.Config) error {
-	if len(l.inputPaths) == 0 && l.inputModules == nil && len(l.overriddenAggregates) == 0 {
+	if len(l.inputPaths) == 0 && l.inputModules == nil && l.overriddenAggregates == nil {
 		return errors.New("nothing provided to lint")
 	}
 
@@ -612,6 +734,14 @@ func (l Linter) validate(conf *config.Config) error {
 		return fmt.Errorf("failed to load custom rules: %w", l.customRuleError)
 	}
 
+	if err := l.validateCustomRuleConfig(); err != nil {
+		return err
+	}
+
+	if l.shardTotal != 0 && (l.shardTotal < 1 || l.shardIndex < 1 || l.shardIndex > l.shardTotal) {
+		return fmt.Errorf("invalid shard %d/%d", l.shardIndex, l.shardTotal)
+	}
+
 	validCategories := rutil.NewSet[string]()
 	validRules := rutil.NewSet[string]()
 
@@ -631,16 +761,9 @@ func (l Linter) validate(conf *config.Config) error {
 	}
 
 	// Add any custom rules
-	for _, module := range l.customRuleModules {
-		parts := rast.UnquotedPath(module.Package.Path)
-		// 1      2     3     4   5
-		// custom.regal.rules.cat.rule
-		if len(parts) != 5 {
-			continue
-		}
-
-		validCategories.Add(parts[3])
-		validRules.Add(parts[4])
+	for category, titles := range l.customRules() {
+		validCategories.Add(category)
+		validRules.Add(titles...)
 	}
 
 	configuredCategories := rutil.NewSet(outil.Keys(conf.Rules)...)
@@ -650,6 +773,14 @@ func (l Linter) validate(conf *config.Config) error {
 		configuredRules.Add(outil.Keys(cat)...)
 	}
 
+	for _, override := range conf.Overrides {
+		configuredCategories.Add(outil.Keys(override.Rules)...)
+
+		for _, cat := range override.Rules {
+			configuredRules.Add(outil.Keys(cat)...)
+		}
+	}
+
 	configuredRules.Add(l.enable...)
 	configuredRules.Add(l.disable...)
 	configuredCategories.Add(l.enableCategory...)
@@ -778,7 +909,7 @@ func (l Linter) prepareRegoArgs(query ast.Body) ([]func(*rego.Rego), error) {
 	return regoArgs, nil
 }
 
-func (l Linter) lint(ctx context.Context, input rules.Input) (report.Report, error) {
+func (l Linter) lint(ctx context.Context, input rules.Input, onFile FileViolationsFunc) (report.Report, error) {
 	l.startTimer(regalmetrics.RegalLintRego)
 	defer l.stopTimer(regalmetrics.RegalLintRego)
 
@@ -787,9 +918,9 @@ func (l Linter) lint(ctx context.Context, input rules.Input) (report.Report, err
 
 	regoReport := report.Report{}
 	regoReport.Aggregates = make(map[string][]report.Aggregate, len(input.FileNames))
-	regoReport.IgnoreDirectives = make(map[string]map[string][]string, len(input.FileNames))
+	regoReport.IgnoreDirectives = make(map[string][]report.IgnoreDirective, len(input.FileNames))
 
-	operationCollect := len(input.FileNames) > 1 || l.useCollectQuery
+	operationCollect := len(input.FileNames) > 1 || l.useCollectQuery || l.isSharded()
 
 	var wg sync.WaitGroup
 
@@ -801,95 +932,98 @@ func (l Linter) lint(ctx context.Context, input rules.Input) (report.Report, err
 	errCh := make(chan error, len(input.FileNames))
 	doneCh := make(chan bool)
 
-	for _, name := range input.FileNames {
-		wg.Add(1)
-
-		go func(name string) {
-			defer wg.Done()
-
-			inputValue, err := transform.ToAST(name, input.FileContent[name], input.Modules[name], operationCollect)
-			if err != nil {
-				errCh <- fmt.Errorf("failed to transform input value: %w", err)
+	collect := func(name string, result report.Report) error {
+		mu.Lock()
+		defer mu.Unlock()
 
-				return
+		if onFile != nil {
+			if err := onFile(name, result.Violations); err != nil {
+				return fmt.Errorf("failed to publish violations for %s: %w", name, err)
 			}
+		}
 
-			evalArgs := []rego.EvalOption{
-				rego.EvalParsedInput(inputValue),
-				rego.EvalInstrument(l.instrumentation),
+		regoReport.Violations = append(regoReport.Violations, result.Violations...)
+		regoReport.Notices = append(regoReport.Notices, result.Notices...)
+
+		for k := range result.Aggregates {
+			// Custom aggregate rules that have been invoked but not returned any data
+			// will return an empty map to signal that they have been called, and that
+			// the aggregate report for this rule should be invoked even when no data
+			// was aggregated. This because the absence of data is exactly what some rules
+			// will want to report on.
+			for _, agg := range result.Aggregates[k] {
+				if len(agg) == 0 {
+					if _, ok := regoReport.Aggregates[k]; !ok {
+						regoReport.Aggregates[k] = make([]report.Aggregate, 0)
+					}
+				} else {
+					regoReport.Aggregates[k] = append(regoReport.Aggregates[k], agg)
+				}
 			}
+		}
 
-			if l.baseCache != nil {
-				evalArgs = append(evalArgs, rego.EvalBaseCache(l.baseCache))
-			}
+		for k := range result.IgnoreDirectives {
+			regoReport.IgnoreDirectives[k] = result.IgnoreDirectives[k]
+		}
 
-			if l.metrics != nil {
-				evalArgs = append(evalArgs, rego.EvalMetrics(l.metrics))
-			}
+		if l.profiling {
+			regoReport.AddProfileEntries(result.AggregateProfile)
+		}
 
-			var prof *profiler.Profiler
-			if l.profiling {
-				prof = profiler.New()
-				evalArgs = append(evalArgs, rego.EvalQueryTracer(prof))
-			}
+		return nil
+	}
 
-			resultSet, err := l.preparedQuery.Eval(ctx, evalArgs...)
-			if err != nil {
-				errCh <- fmt.Errorf("error encountered in query evaluation %w", err)
+	lintAndCollect := func(w worker, name string) {
+		result, err := l.lintFile(ctx, w, name, input, operationCollect)
+		if err == nil {
+			err = collect(name, result)
+		}
 
-				return
-			}
+		if err != nil {
+			errCh <- err
+		}
+	}
 
-			result, err := report.FromResultSet(resultSet, false)
-			if err != nil {
-				errCh <- fmt.Errorf("failed to convert result set to report: %w", err)
+	if l.concurrency > 0 {
+		workers, err := l.prepareWorkers(ctx, min(l.concurrency, len(input.FileNames)))
+		if err != nil {
+			return report.Report{}, err
+		}
 
-				return
-			}
+		names := make(chan string, len(input.FileNames))
+		for _, name := range input.FileNames {
+			names <- name
+		}
 
-			if l.profiling {
-				// Perhaps we'll want to make this number configurable later, but do note that
-				// this is only the top 10 locations for a *single* file, not the final report.
-				profRep := prof.ReportTopNResults(10, []string{"total_time_ns"})
+		close(names)
 
-				result.AggregateProfile = make(map[string]report.ProfileEntry, len(profRep))
+		for _, w := range workers {
+			wg.Add(1)
 
-				for _, rs := range profRep {
-					result.AggregateProfile[rs.Location.String()] = regalmetrics.FromExprStats(rs)
-				}
-			}
+			go func(w worker) {
+				defer wg.Done()
 
-			mu.Lock()
-			defer mu.Unlock()
-
-			regoReport.Violations = append(regoReport.Violations, result.Violations...)
-			regoReport.Notices = append(regoReport.Notices, result.Notices...)
-
-			for k := range result.Aggregates {
-				// Custom aggregate rules that have been invoked but not returned any data
-				// will return an empty map to signal that they have been called, and that
-				// the aggregate report for this rule should be invoked even when no data
-				// was aggregated. This because the absence of data is exactly what some rules
-				// will want to report on.
-				for _, agg := range result.Aggregates[k] {
-					if len(agg) == 0 {
-						if _, ok := regoReport.Aggregates[k]; !ok {
-							regoReport.Aggregates[k] = make([]report.Aggregate, 0)
-						}
-					} else {
-						regoReport.Aggregates[k] = append(regoReport.Aggregates[k], agg)
+				for name := range names {
+					if ctx.Err() != nil {
+						return
 					}
+
+					lintAndCollect(w, name)
 				}
-			}
+			}(w)
+		}
+	} else {
+		w := worker{query: l.preparedQuery, baseCache: l.baseCache}
 
-			for k := range result.IgnoreDirectives {
-				regoReport.IgnoreDirectives[k] = result.IgnoreDirectives[k]
-			}
+		for _, name := range input.FileNames {
+			wg.Add(1)
 
-			if l.profiling {
-				regoReport.AddProfileEntries(result.AggregateProfile)
-			}
-		}(name)
+			go func(name string) {
+				defer wg.Done()
+
+				lintAndCollect(w, name)
+			}(name)
+		}
 	}
 
 	go func() {
@@ -904,14 +1038,136 @@ func (l Linter) lint(ctx context.Context, input rules.Input) (report.Report, err
 	case err := <-errCh:
 		return report.Report{}, fmt.Errorf("error encountered in rule evaluation %w", err)
 	case <-doneCh:
+		if ctx.Err() != nil {
+			return report.Report{}, fmt.Errorf("context cancelled: %w", ctx.Err())
+		}
+
+		// errors may have been sent just before the last goroutine finished
+		select {
+		case err := <-errCh:
+			return report.Report{}, fmt.Errorf("error encountered in rule evaluation %w", err)
+		default:
+		}
+
 		return regoReport, nil
 	}
 }
 
+// worker holds the state needed to lint files independently of other workers.
+type worker struct {
+	query     *rego.PreparedEvalQuery
+	baseCache topdown.BaseCache
+}
+
+// prepareWorkers creates n workers, each with its own prepared query, and its own base
+// cache if caching was enabled for the linter. The first worker reuses the query already
+// prepared for the linter.
+func (l Linter) prepareWorkers(ctx context.Context, n int) ([]worker, error) {
+	workers := make([]worker, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+
+	for i := range workers {
+		if l.baseCache != nil {
+			workers[i].baseCache = l.baseCache
+			if i > 0 {
+				workers[i].baseCache = cache.NewBaseCache()
+			}
+		}
+
+		if i == 0 {
+			workers[i].query = l.preparedQuery
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			workers[i].query, errs[i] = l.prepareQuery(ctx)
+		}(i)
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("failed to prepare workers: %w", err)
+	}
+
+	return workers, nil
+}
+
+func (l Linter) lintFile(
+	ctx context.Context,
+	w worker,
+	name string,
+	input rules.Input,
+	operationCollect bool,
+) (report.Report, error) {
+	inputValue, err := transform.ToAST(name, input.FileContent[name], input.Modules[name], operationCollect)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("failed to transform input value: %w", err)
+	}
+
+	evalArgs := []rego.EvalOption{
+		rego.EvalParsedInput(inputValue),
+		rego.EvalInstrument(l.instrumentation),
+	}
+
+	if w.baseCache != nil {
+		evalArgs = append(evalArgs, rego.EvalBaseCache(w.baseCache))
+	}
+
+	if l.metrics != nil {
+		evalArgs = append(evalArgs, rego.EvalMetrics(l.metrics))
+	}
+
+	var prof *profiler.Profiler
+	if l.profiling {
+		prof = profiler.New()
+		evalArgs = append(evalArgs, rego.EvalQueryTracer(prof))
+	}
+
+	resultSet, err := w.query.Eval(ctx, evalArgs...)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("error encountered in query evaluation %w", err)
+	}
+
+	result, err := report.FromResultSet(resultSet, false)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("failed to convert result set to report: %w", err)
+	}
+
+	if directives := ignoreDirectives(name, input.Modules[name]); len(directives) > 0 {
+		result.IgnoreDirectives = map[string][]report.IgnoreDirective{name: directives}
+		result.Violations = filterIgnored(
+			result.Violations, result.IgnoreDirectives, l.ignoreDirectivesRequireReason(), time.Now(),
+		)
+	}
+
+	setFingerprints(result.Violations, input.Modules)
+
+	if l.profiling {
+		// Perhaps we'll want to make this number configurable later, but do note that
+		// this is only the top 10 locations for a *single* file, not the final report.
+		profRep := prof.ReportTopNResults(10, []string{"total_time_ns"})
+
+		result.AggregateProfile = make(map[string]report.ProfileEntry, len(profRep))
+
+		for _, rs := range profRep {
+			result.AggregateProfile[rs.Location.String()] = regalmetrics.FromExprStats(rs)
+		}
+	}
+
+	return result, nil
+}
+
 func (l Linter) lintWithAggregateRules(
 	ctx context.Context,
 	aggregates map[string][]report.Aggregate,
-	ignoreDirectives map[string]map[string][]string,
 ) (report.Report, error) {
 	l.startTimer(regalmetrics.RegalLintRegoAggregate)
 	defer l.stopTimer(regalmetrics.RegalLintRegoAggregate)
@@ -926,7 +1182,6 @@ func (l Linter) lintWithAggregateRules(
 		// There is no file provided in input here, but we'll provide *something* for
 		// consistency, and to avoid silently failing with undefined should someone
 		// refer to input.regal in an aggregate_report rule
-		"ignore_directives": ignoreDirectives,
 		"regal": map[string]any{
 			"operations": []string{"aggregate"},
 			"file": map[string]any{
@@ -983,6 +1238,34 @@ func (l Linter) lintWithAggregateRules(
 	return result, nil
 }
 
+func (l Linter) ignoreDirectivesRequireReason() bool {
+	return l.combinedCfg != nil && l.combinedCfg.Ignore.Directives != nil && l.combinedCfg.Ignore.Directives.RequireReason
+}
+
+// isSharded returns true if a shard was set, even when the only shard is 1/1, as aggregate rules
+// are then left for a later run to evaluate all the same.
+func (l Linter) isSharded() bool {
+	return l.shardTotal > 0
+}
+
+// shard returns the paths belonging to the shard of this linter, or all paths if not sharded.
+func (l Linter) shard(paths []string) []string {
+	if !l.isSharded() {
+		return paths
+	}
+
+	sorted := slices.Clone(paths)
+	slices.Sort(sorted)
+
+	shard := make([]string, 0, len(sorted)/l.shardTotal+1)
+
+	for i := l.shardIndex - 1; i < len(sorted); i += l.shardTotal {
+		shard = append(shard, sorted[i])
+	}
+
+	return shard
+}
+
 func (l Linter) startTimer(name string) {
 	if l.metrics != nil {
 		l.metrics.Timer(name).Start()
@@ -3,11 +3,14 @@ package linter
 import (
 	"bytes"
 	"embed"
+	"errors"
+	"fmt"
 	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
 
+	"github.com/open-policy-agent/opa/v1/ast"
 	"github.com/open-policy-agent/opa/v1/topdown"
 
 	"github.com/open-policy-agent/regal/bundle"
@@ -98,6 +101,52 @@ r := input.foo[_]
 	}
 }
 
+func TestLintWithUserConfigOverrides(t *testing.T) {
+	t.Parallel()
+
+	policy := "package p\n\n# TODO: remove\ncamelCase := true\n"
+	input := rules.NewInput(
+		map[string]string{"policy/p.rego": policy, "scratch/p.rego": policy},
+		map[string]*ast.Module{
+			"policy/p.rego":  parse.MustParseModule(policy),
+			"scratch/p.rego": parse.MustParseModule(policy),
+		},
+	)
+
+	userConfig := config.Config{
+		Overrides: []config.Override{{
+			Files: []string{"scratch/**"},
+			Rules: map[string]config.Category{
+				"style": {
+					"prefer-snake-case": config.Rule{Level: "warning"},
+					"todo-comment":      config.Rule{Level: "ignore"},
+				},
+			},
+		}},
+	}
+
+	result := testutil.Must(NewLinter().WithUserConfig(userConfig).WithInputModules(&input).Lint(t.Context()))(t)
+
+	actual := make([]string, 0, len(result.Violations))
+	for _, violation := range result.Violations {
+		if violation.Title == "prefer-snake-case" || violation.Title == "todo-comment" {
+			actual = append(actual, violation.Location.File+" "+violation.Title+" "+violation.Level)
+		}
+	}
+
+	slices.Sort(actual)
+
+	expected := []string{
+		"policy/p.rego prefer-snake-case error",
+		"policy/p.rego todo-comment error",
+		"scratch/p.rego prefer-snake-case warning",
+	}
+
+	if !slices.Equal(actual, expected) {
+		t.Errorf("expected violations %v, got %v", expected, actual)
+	}
+}
+
 func TestLintWithUserConfigTable(t *testing.T) {
 	t.Parallel()
 
@@ -358,6 +407,65 @@ func TestLintWithCustomRuleAndCustomConfig(t *testing.T) {
 	testutil.AssertNumViolations(t, 0, testutil.Must(linter.Lint(t.Context()))(t))
 }
 
+func TestLintWithCustomRuleConfigSchema(t *testing.T) {
+	t.Parallel()
+
+	rule := func(title, metadata string) string {
+		return "# METADATA\n# description: " + title + "\n" + metadata + "package custom.regal.rules.acme[\"" + title +
+			"\"]\n\nimport data.regal.result\n\nreport contains result.fail(rego.metadata.chain(), {}) if false\n"
+	}
+
+	root := testutil.TempDirectoryOf(t, map[string]string{
+		"rules/declared.rego": rule("declared", "# custom:\n#   config:\n#     max-things:\n#       type: integer\n"),
+		"rules/sidecar.rego":  rule("sidecar", ""),
+		"rules/sidecar.schema.json": `{
+			"type": "object",
+			"properties": {"names": {"type": "array", "items": {"type": "string"}}},
+			"additionalProperties": false
+		}`,
+		"rules/unchecked.rego": rule("unchecked", ""),
+		".regal/config.yaml": `rules:
+  acme:
+    declared:
+      level: error
+      max_things: 5
+    sidecar:
+      names:
+        - ok
+        - 1
+    unchecked:
+      anything: goes
+`,
+	})
+
+	configFile := filepath.Join(root, ".regal", "config.yaml")
+
+	linter := NewLinter().
+		WithUserConfig(testutil.Must(config.FromPath(configFile))(t)).
+		WithCustomRules([]string{filepath.Join(root, "rules")}).
+		WithInputModules(test.InputPolicy("p/p.rego", "package p\n"))
+
+	_, err := linter.Lint(t.Context())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	expected := []string{
+		configFile + ":5: rules.acme.declared.max_things: additional property max_things is not allowed",
+		configFile + ":9: rules.acme.sidecar.names[1]: invalid type. Expected: string, given: integer",
+	}
+
+	for _, exp := range expected {
+		if !strings.Contains(err.Error(), exp) {
+			t.Errorf("expected error to contain %q, got %q", exp, err.Error())
+		}
+	}
+
+	if strings.Contains(err.Error(), "unchecked") {
+		t.Errorf("expected rule without schema not to be validated, got %q", err.Error())
+	}
+}
+
 func TestLintMergedConfigInheritsLevelFromProvided(t *testing.T) {
 	t.Parallel()
 
@@ -486,6 +594,520 @@ func TestLintWithAggregateRule(t *testing.T) {
 	}
 }
 
+func TestLintStream(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]string{
+		"foo.rego": "package foo\n\nimport data.bar\n\ndefault allow := false\n",
+		"bar.rego": "package bar\n\nimport data.foo.allow\n\ncamelCase := 1\n",
+		"baz.rego": "package baz\n",
+	}
+
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("prefer-package-imports", "prefer-snake-case").
+		WithInputModules(&input)
+
+	streamed := make(map[string][]report.Violation)
+
+	result := testutil.Must(linter.LintStream(t.Context(), func(file string, violations []report.Violation) error {
+		if _, ok := streamed[file]; ok {
+			t.Errorf("file %s published more than once", file)
+		}
+
+		streamed[file] = violations
+
+		return nil
+	}))(t)
+
+	testutil.AssertNumViolations(t, 2, result)
+
+	if len(streamed) != 3 {
+		t.Fatalf("expected all 3 files to be published, got %d", len(streamed))
+	}
+
+	if len(streamed["bar.rego"]) != 1 || streamed["bar.rego"][0].Title != "prefer-snake-case" {
+		t.Errorf("expected prefer-snake-case violation to be streamed for bar.rego, got %v", streamed["bar.rego"])
+	}
+
+	// aggregate violations are only found in the final report
+	aggregates := 0
+
+	for _, v := range result.Violations {
+		if v.IsAggregate {
+			aggregates++
+
+			if v.Title != "prefer-package-imports" {
+				t.Errorf("expected aggregate violation to be prefer-package-imports, got %s", v.Title)
+			}
+		}
+	}
+
+	if aggregates != 1 {
+		t.Errorf("expected 1 aggregate violation, got %d", aggregates)
+	}
+}
+
+func TestLintStreamCallbackError(t *testing.T) {
+	t.Parallel()
+
+	linter := NewLinter().WithInputModules(test.InputPolicy("p.rego", "package p\n"))
+
+	_, err := linter.LintStream(t.Context(), func(string, []report.Violation) error {
+		return errors.New("write failed")
+	})
+	if err == nil || !strings.Contains(err.Error(), "write failed") {
+		t.Errorf("expected error from callback to be returned, got %v", err)
+	}
+}
+
+func TestLintWithConcurrency(t *testing.T) {
+	t.Parallel()
+
+	policies := make(map[string]string, 20)
+	for i := range 20 {
+		policies[fmt.Sprintf("p%02d.rego", i)] = fmt.Sprintf(
+			"package p%d\n\nimport data.p.unresolved\n\ncamelCase := %d\n", i, i,
+		)
+	}
+
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("prefer-snake-case", "unresolved-import").
+		WithInputModules(&input).
+		WithBaseCache(cache.NewBaseCache())
+
+	expected := testutil.Must(linter.Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 40, expected)
+
+	for _, concurrency := range []int{1, 3, 32} {
+		result := testutil.Must(linter.WithConcurrency(concurrency).Lint(t.Context()))(t)
+
+		if !slices.EqualFunc(expected.Violations, result.Violations, func(a, b report.Violation) bool {
+			return a.Title == b.Title && a.Location.String() == b.Location.String()
+		}) {
+			t.Errorf("expected same violations in same order with concurrency %d, got %v", concurrency, result.Violations)
+		}
+	}
+}
+
+func TestLintWithShards(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]string{
+		"a.rego": "package a\n\nimport data.unresolved\n",
+		"b.rego": "package b\n\nimport data.unresolved\n",
+		"c.rego": "package c\n\n# regal ignore:unresolved-import\nimport data.unresolved\n",
+	}
+
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("unresolved-import").
+		WithInputModules(&input).
+		WithExportAggregates(true)
+
+	shards := make([]report.Report, 0, 2)
+
+	for i := range 2 {
+		result := testutil.Must(linter.WithShard(i+1, 2).Lint(t.Context()))(t)
+
+		// aggregate rules are not evaluated for individual shards
+		testutil.AssertNumViolations(t, 0, result)
+
+		shards = append(shards, result)
+	}
+
+	if shards[0].Summary.FilesScanned != 2 || shards[1].Summary.FilesScanned != 1 {
+		t.Fatalf("expected files to be split 2/1 between shards, got %d/%d",
+			shards[0].Summary.FilesScanned, shards[1].Summary.FilesScanned)
+	}
+
+	// a single shard covers all files, but aggregate rules are still left for merging
+	single := testutil.Must(linter.WithShard(1, 1).Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 0, single)
+
+	if single.Summary.FilesScanned != 3 || len(single.Aggregates) == 0 {
+		t.Errorf("expected all files to be scanned and aggregates exported for shard 1/1")
+	}
+
+	merged := report.Merge(shards...)
+
+	result := testutil.Must(NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("unresolved-import").
+		WithAggregates(merged.Aggregates).
+		WithIgnoreDirectives(merged.IgnoreDirectives).
+		Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 2, result)
+
+	for _, v := range result.Violations {
+		if v.Location.File == "c.rego" {
+			t.Errorf("expected ignore directive in c.rego to be respected")
+		}
+	}
+}
+
+func TestLintWithShardsFingerprints(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]string{
+		"a.rego": "package a\n\nimport data.unresolved\n\nallow if data.b.missing\n",
+		"b.rego": "package b\n\ndeny if data.a.missing\n",
+	}
+
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("unresolved-import", "unresolved-reference").
+		WithInputModules(&input)
+
+	unsharded := testutil.Must(linter.Lint(t.Context()))(t)
+
+	shards := make([]report.Report, 0, 2)
+	for i := range 2 {
+		shards = append(shards, testutil.Must(linter.WithExportAggregates(true).WithShard(i+1, 2).Lint(t.Context()))(t))
+	}
+
+	merged := report.Merge(shards...)
+
+	result := testutil.Must(NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("unresolved-import", "unresolved-reference").
+		WithAggregates(merged.Aggregates).
+		WithIgnoreDirectives(merged.IgnoreDirectives).
+		Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 3, unsharded)
+
+	fingerprints := func(violations []report.Violation) []string {
+		fps := make([]string, 0, len(violations))
+		for i := range violations {
+			fps = append(fps, violations[i].Fingerprint)
+		}
+
+		return fps
+	}
+
+	if exp, got := fingerprints(unsharded.Violations), fingerprints(result.Violations); !slices.Equal(exp, got) {
+		t.Errorf("expected fingerprints of merged shards %v to equal those of unsharded run %v", got, exp)
+	}
+}
+
+func TestLintWithInvalidShard(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewLinter().WithInputModules(test.InputPolicy("p.rego", "package p\n")).WithShard(3, 2).Lint(t.Context())
+	if err == nil || !strings.Contains(err.Error(), "invalid shard 3/2") {
+		t.Errorf("expected invalid shard error, got %v", err)
+	}
+}
+
+func TestLintViolationFingerprints(t *testing.T) {
+	t.Parallel()
+
+	lintPolicy := func(policy string) []report.Violation {
+		t.Helper()
+
+		return testutil.Must(NewLinter().
+			WithDisableAll(true).
+			WithEnabledRules("print-or-trace-call").
+			WithInputModules(test.InputPolicy("p/p.rego", policy)).
+			Lint(t.Context()))(t).Violations
+	}
+
+	before := lintPolicy(`package p
+
+allow if {
+	print("one")
+	print("one")
+}
+`)
+	after := lintPolicy(`package p
+
+import data.foo
+
+deny if {
+	true
+}
+
+allow if {
+	  print("one")
+	print("one")
+}
+`)
+
+	if len(before) != 2 || len(after) != 2 {
+		t.Fatalf("expected 2 violations before and after, got %d and %d", len(before), len(after))
+	}
+
+	if before[0].Fingerprint == "" || before[0].Fingerprint == before[1].Fingerprint {
+		t.Errorf("expected unique fingerprints for identical violations, got %q and %q",
+			before[0].Fingerprint, before[1].Fingerprint)
+	}
+
+	for i := range before {
+		if before[i].Location.Row == after[i].Location.Row {
+			t.Fatalf("expected violation to have moved")
+		}
+
+		if before[i].Fingerprint != after[i].Fingerprint {
+			t.Errorf("expected fingerprint to be stable when code moves, got %q and %q",
+				before[i].Fingerprint, after[i].Fingerprint)
+		}
+	}
+}
+
+func TestLintIgnoreDirectives(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		directive     string
+		requireReason bool
+		related       string
+	}{
+		"without options": {
+			directive: "# regal ignore:prefer-snake-case",
+		},
+		"among other rules": {
+			directive: "# regal ignore:line-length, prefer-snake-case",
+		},
+		"until in the future": {
+			directive: "# regal ignore:prefer-snake-case until=2999-12-31",
+		},
+		"until in the past": {
+			directive: "# regal ignore:prefer-snake-case until=2000-01-01",
+			related:   "ignore directive expired on 2000-01-01",
+		},
+		"until invalid": {
+			directive: "# regal ignore:prefer-snake-case until=tomorrow",
+			related:   `ignore directive has invalid until date "tomorrow", expected YYYY-MM-DD`,
+		},
+		"reason required and provided": {
+			directive:     `# regal ignore:prefer-snake-case reason="external naming convention"`,
+			requireReason: true,
+		},
+		"reason required but missing": {
+			directive:     "# regal ignore:prefer-snake-case until=2999-12-31",
+			requireReason: true,
+			related:       "ignore directive provides no reason, which is required by configuration",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			userConfig := config.Config{}
+			if tc.requireReason {
+				userConfig.Ignore.Directives = &config.Directives{RequireReason: true}
+			}
+
+			result := testutil.Must(NewLinter().
+				WithDisableAll(true).
+				WithEnabledRules("prefer-snake-case").
+				WithUserConfig(userConfig).
+				WithInputModules(test.InputPolicy("p.rego", "package p\n\n"+tc.directive+"\ncamelCase := true\n")).
+				Lint(t.Context()))(t)
+
+			if tc.related == "" {
+				testutil.AssertNumViolations(t, 0, result)
+
+				return
+			}
+
+			testutil.AssertNumViolations(t, 1, result)
+
+			related := result.Violations[0].RelatedLocations
+			if len(related) != 1 || related[0].Message != tc.related || related[0].Location.Row != 3 {
+				t.Errorf("expected related location on row 3 with message %q, got %v", tc.related, related)
+			}
+		})
+	}
+}
+
+func TestLintIgnoreDirectivePlacement(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy   string
+		rules    []string
+		expected int
+	}{
+		"line before": {
+			policy: "# regal ignore:prefer-snake-case\ncamelCase := \"yes\"\n",
+			rules:  []string{"prefer-snake-case"},
+		},
+		"same line": {
+			policy: "camelCase := \"yes\" # regal ignore:prefer-snake-case\n",
+			rules:  []string{"prefer-snake-case"},
+		},
+		"same line after other comment": {
+			policy: "camelCase := \"yes\" # camelCase is nice! # regal ignore:prefer-snake-case\n",
+			rules:  []string{"prefer-snake-case"},
+		},
+		"same line as todo comment": {
+			policy: "x := \"yes\" # TODO! make it no # regal ignore:todo-comment\n",
+			rules:  []string{"todo-comment"},
+		},
+		"multiple rules": {
+			policy: "# regal ignore:prefer-snake-case,use-assignment-operator\ndefault camelCase = \"yes\"\n",
+			rules:  []string{"prefer-snake-case", "use-assignment-operator"},
+		},
+		"other rule not ignored": {
+			policy:   "# regal ignore:prefer-snake-case,todo-comment\ndefault camelCase = \"yes\"\n",
+			rules:    []string{"prefer-snake-case", "use-assignment-operator"},
+			expected: 1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result := testutil.Must(NewLinter().
+				WithDisableAll(true).
+				WithEnabledRules(tc.rules...).
+				WithInputModules(test.InputPolicy("p.rego", "package p\n\n"+tc.policy)).
+				Lint(t.Context()))(t)
+
+			testutil.AssertNumViolations(t, tc.expected, result)
+		})
+	}
+}
+
+func TestLintIgnoreDirectivesInAggregateRules(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]string{
+		"a.rego": "package a\n\nimport data.unresolved\n",
+		"b.rego": "package b\n\n# regal ignore:unresolved-import\nimport data.unresolved\n",
+	}
+
+	input := rules.NewInput(policies, util.MapValues(policies, parse.MustParseModule))
+
+	linter := NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("unresolved-import")
+
+	result := testutil.Must(linter.WithInputModules(&input).Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 1, result)
+
+	if file := result.Violations[0].Location.File; file != "a.rego" {
+		t.Errorf("expected only violation in a.rego, got one in %s", file)
+	}
+
+	exported := testutil.Must(linter.
+		WithInputModules(&input).
+		WithExportAggregates(true).
+		WithShard(1, 1).
+		Lint(t.Context()))(t)
+
+	if directives := exported.IgnoreDirectives["b.rego"]; len(directives) != 1 ||
+		!slices.Equal(directives[0].Rules, []string{"unresolved-import"}) {
+		t.Fatalf("expected ignore directive for unresolved-import exported for b.rego, got %v", directives)
+	}
+
+	// without the ignore directives of the linted files, aggregate violations can't be ignored
+	withoutDirectives := testutil.Must(linter.WithAggregates(exported.Aggregates).Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 2, withoutDirectives)
+
+	withDirectives := testutil.Must(linter.
+		WithAggregates(exported.Aggregates).
+		WithIgnoreDirectives(exported.IgnoreDirectives).
+		Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 1, withDirectives)
+
+	if file := withDirectives.Violations[0].Location.File; file != "a.rego" {
+		t.Errorf("expected only violation in a.rego, got one in %s", file)
+	}
+}
+
+func TestLintReportsIgnoreDirectives(t *testing.T) {
+	t.Parallel()
+
+	result := testutil.Must(NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("prefer-snake-case").
+		WithInputModules(test.InputPolicy("p.rego", `package p
+
+# this is fine # regal ignore:prefer-snake-case,line-length until=2999-12-31 reason="external naming convention"
+camelCase := true
+`)).
+		Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 0, result)
+
+	directives := result.IgnoreDirectives["p.rego"]
+	if len(directives) != 1 {
+		t.Fatalf("expected 1 ignore directive, got %v", result.IgnoreDirectives)
+	}
+
+	directive := directives[0]
+
+	if !slices.Equal(directive.Rules, []string{"prefer-snake-case", "line-length"}) {
+		t.Errorf("expected rules prefer-snake-case and line-length, got %v", directive.Rules)
+	}
+
+	if directive.Until != "2999-12-31" || directive.Reason != "external naming convention" {
+		t.Errorf("expected until 2999-12-31 and reason, got %q and %q", directive.Until, directive.Reason)
+	}
+
+	if directive.Location.Row != 3 || directive.Location.Column != 1 {
+		t.Errorf("expected directive at 3:1, got %d:%d", directive.Location.Row, directive.Location.Column)
+	}
+}
+
+func TestLintUnusedIgnoreDirectives(t *testing.T) {
+	t.Parallel()
+
+	result := testutil.Must(NewLinter().
+		WithDisableAll(true).
+		WithEnabledRules("prefer-snake-case", "unused-ignore-directive").
+		WithInputModules(test.InputPolicy("p.rego", `package p
+
+# regal ignore:prefer-snake-case
+camelCase := true
+
+# regal ignore:prefer-snake-case,prefer-snake-cas
+snake_case := true
+`)).
+		Lint(t.Context()))(t)
+
+	testutil.AssertNumViolations(t, 2, result)
+
+	descriptions := make([]string, 0, len(result.Violations))
+	for _, violation := range result.Violations {
+		if violation.Title != "unused-ignore-directive" || violation.Location.Row != 6 {
+			t.Errorf("expected unused-ignore-directive violation on row 6, got %s on row %d",
+				violation.Title, violation.Location.Row)
+		}
+
+		descriptions = append(descriptions, violation.Description)
+	}
+
+	slices.Sort(descriptions)
+
+	if !slices.Equal(descriptions, []string{
+		"Ignore directive for prefer-snake-case suppressed no violations",
+		"Ignore directive names unknown rule prefer-snake-cas",
+	}) {
+		t.Errorf("unexpected descriptions: %v", descriptions)
+	}
+}
+
 func TestEnabledRules(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,82 @@
+package report
+
+import "strings"
+
+// Diff describes the difference in violations between two reports.
+type Diff struct {
+	// Introduced are violations found only in the new report.
+	Introduced []Violation `json:"introduced"`
+	// Fixed are violations found only in the old report.
+	Fixed []Violation `json:"fixed"`
+	// Unchanged are violations found in both reports, as they appear in the new report.
+	Unchanged []Violation `json:"unchanged"`
+}
+
+// DiffReports compares the violations of an old and a new report. Violations are matched by
+// their fingerprint when all violations of both reports have one, and otherwise by their rule,
+// file and text, meaning that violations are considered unchanged even when moved within a file.
+// Identical violations are matched one-to-one, so that a duplicate added to a file counts as
+// introduced.
+func DiffReports(oldReport, newReport Report) Diff {
+	useFingerprints := hasFingerprints(oldReport.Violations) && hasFingerprints(newReport.Violations)
+	diffKey := func(v Violation) string {
+		if useFingerprints {
+			return v.Fingerprint
+		}
+
+		text := ""
+		if v.Location.Text != nil {
+			text = strings.Join(strings.Fields(*v.Location.Text), " ")
+		}
+
+		return strings.Join([]string{v.Category, v.Title, v.Location.File, text}, "\x00")
+	}
+
+	diff := Diff{
+		Introduced: []Violation{},
+		Fixed:      []Violation{},
+		Unchanged:  []Violation{},
+	}
+
+	remaining := make(map[string][]Violation, len(oldReport.Violations))
+
+	for _, v := range oldReport.Violations { //nolint:gocritic
+		key := diffKey(v)
+		remaining[key] = append(remaining[key], v)
+	}
+
+	for _, v := range newReport.Violations { //nolint:gocritic
+		key := diffKey(v)
+
+		if len(remaining[key]) > 0 {
+			remaining[key] = remaining[key][1:]
+
+			diff.Unchanged = append(diff.Unchanged, v)
+		} else {
+			diff.Introduced = append(diff.Introduced, v)
+		}
+	}
+
+	// iterate over the old report again, rather than the map, to retain order
+	for _, v := range oldReport.Violations { //nolint:gocritic
+		key := diffKey(v)
+
+		if len(remaining[key]) > 0 {
+			diff.Fixed = append(diff.Fixed, remaining[key][0])
+
+			remaining[key] = remaining[key][1:]
+		}
+	}
+
+	return diff
+}
+
+func hasFingerprints(violations []Violation) bool {
+	for i := range violations {
+		if violations[i].Fingerprint == "" {
+			return false
+		}
+	}
+
+	return true
+}
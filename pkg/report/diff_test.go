@@ -0,0 +1,111 @@
+package report
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffReports(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		oldViolations []Violation
+		newViolations []Violation
+		introduced    []string
+		fixed         []string
+		unchanged     []string
+	}{
+		"no violations": {},
+		"matched by fingerprint": {
+			oldViolations: []Violation{
+				violation("a", "p.rego", "x := 1", "fp-a"),
+				violation("b", "p.rego", "y := 2", "fp-b"),
+			},
+			newViolations: []Violation{
+				violation("b", "p.rego", "y := 2", "fp-b"),
+				violation("c", "p.rego", "z := 3", "fp-c"),
+			},
+			introduced: []string{"c"},
+			fixed:      []string{"a"},
+			unchanged:  []string{"b"},
+		},
+		"fingerprint takes precedence over location": {
+			oldViolations: []Violation{violation("a", "p.rego", "x := 1", "fp-1")},
+			newViolations: []Violation{violation("a", "p.rego", "x := 1", "fp-2")},
+			introduced:    []string{"a"},
+			fixed:         []string{"a"},
+		},
+		"matched by rule, file and text without fingerprints": {
+			oldViolations: []Violation{
+				violation("a", "p.rego", "x := 1", ""),
+				violation("a", "q.rego", "x := 1", ""),
+			},
+			newViolations: []Violation{
+				violation("a", "p.rego", "x  :=  1", ""),
+				violation("a", "r.rego", "x := 1", ""),
+			},
+			introduced: []string{"a"},
+			fixed:      []string{"a"},
+			unchanged:  []string{"a"},
+		},
+		"matched without fingerprints when only one report has them": {
+			oldViolations: []Violation{violation("a", "p.rego", "x := 1", "")},
+			newViolations: []Violation{violation("a", "p.rego", "x := 1", "fp-a")},
+			unchanged:     []string{"a"},
+		},
+		"duplicates matched one-to-one": {
+			oldViolations: []Violation{violation("a", "p.rego", "x := 1", "")},
+			newViolations: []Violation{
+				violation("a", "p.rego", "x := 1", ""),
+				violation("a", "p.rego", "x := 1", ""),
+			},
+			introduced: []string{"a"},
+			unchanged:  []string{"a"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diff := DiffReports(Report{Violations: tc.oldViolations}, Report{Violations: tc.newViolations})
+
+			for _, section := range []struct {
+				name     string
+				actual   []Violation
+				expected []string
+			}{
+				{"introduced", diff.Introduced, tc.introduced},
+				{"fixed", diff.Fixed, tc.fixed},
+				{"unchanged", diff.Unchanged, tc.unchanged},
+			} {
+				if section.actual == nil {
+					t.Errorf("expected %s to be empty rather than nil", section.name)
+				}
+
+				if titles := violationTitles(section.actual); !slices.Equal(titles, section.expected) {
+					t.Errorf("expected %s %v, got %v", section.name, section.expected, titles)
+				}
+			}
+		})
+	}
+}
+
+func violation(title, file, text, fingerprint string) Violation {
+	return Violation{
+		Category:    "style",
+		Title:       title,
+		Location:    Location{File: file, Text: &text},
+		Fingerprint: fingerprint,
+	}
+}
+
+func violationTitles(violations []Violation) []string {
+	var titles []string
+
+	for i := range violations {
+		titles = append(titles, violations[i].Title)
+	}
+
+	return titles
+}
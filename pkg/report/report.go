@@ -1,8 +1,14 @@
 package report
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/open-policy-agent/opa/v1/rego"
 
@@ -33,6 +39,12 @@ type Location struct {
 	Offset int       `json:"offset,omitempty"`
 }
 
+// RelatedLocation is a location related to a violation, with a message describing how.
+type RelatedLocation struct {
+	Message  string   `json:"message"`
+	Location Location `json:"location"`
+}
+
 // Violation describes any violation found by Regal.
 type Violation struct {
 	Title            string            `json:"title"`
@@ -41,7 +53,43 @@ type Violation struct {
 	Level            string            `json:"level"`
 	RelatedResources []RelatedResource `json:"related_resources,omitempty"`
 	Location         Location          `json:"location"`
-	IsAggregate      bool              `json:"-"`
+	// RelatedLocations are other places in the code relevant to the violation, like the
+	// original definition of a rule reported as a duplicate.
+	RelatedLocations []RelatedLocation `json:"related_locations,omitempty"`
+	// Fingerprint identifies a violation independently of its exact location in a file,
+	// allowing it to be tracked across changes to unrelated code. See Fingerprint.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Fix is the fix for the violation, if one is available and was requested.
+	Fix         *Fix `json:"fix,omitempty"`
+	IsAggregate bool `json:"-"`
+}
+
+// Fix describes the changes an automatic fix would make to resolve a violation.
+type Fix struct {
+	Description string     `json:"description"`
+	Edits       []TextEdit `json:"edits"`
+}
+
+// TextEdit replaces the text from Start up until (but not including) End with NewText.
+// Rows and columns are 1-based, like in Location. An End position on the row following
+// the last line of a file denotes the end of the file.
+type TextEdit struct {
+	Start   Position `json:"start"`
+	End     Position `json:"end"`
+	NewText string   `json:"new_text"`
+}
+
+// IgnoreDirective is an inline directive, like `# regal ignore:line-length`, suppressing violations
+// of the listed rules on the line of the directive, or the line following it. Directives may
+// optionally provide a date until which they apply, and a reason for the suppression:
+//
+//	# regal ignore:line-length until=2026-12-31 reason="generated code"
+type IgnoreDirective struct {
+	Location Location `json:"location"`
+	Rules    []string `json:"rules"`
+	// Until is the last date (YYYY-MM-DD) the directive applies, if provided.
+	Until  string `json:"until,omitempty"`
+	Reason string `json:"reason,omitempty"`
 }
 
 // Notice describes any notice found by Regal.
@@ -64,14 +112,15 @@ type Summary struct {
 type Report struct {
 	// We don't have aggregates when publishing the final report (see JSONReporter), so omitempty is needed here
 	// to avoid surfacing a null/empty field.
-	Aggregates       map[string][]Aggregate         `json:"aggregates,omitempty"`
-	Metrics          map[string]any                 `json:"metrics,omitempty"`
-	AggregateProfile map[string]ProfileEntry        `json:"-"`
-	IgnoreDirectives map[string]map[string][]string `json:"ignore_directives,omitempty"`
-	Violations       []Violation                    `json:"violations"`
-	Notices          []Notice                       `json:"notices,omitempty"`
-	Profile          []ProfileEntry                 `json:"profile,omitempty"`
-	Summary          Summary                        `json:"summary"`
+	Aggregates       map[string][]Aggregate  `json:"aggregates,omitempty"`
+	Metrics          map[string]any          `json:"metrics,omitempty"`
+	AggregateProfile map[string]ProfileEntry `json:"-"`
+	// IgnoreDirectives are the inline ignore directives found in the linted files, keyed by file name.
+	IgnoreDirectives map[string][]IgnoreDirective `json:"ignore_directives,omitempty"`
+	Violations       []Violation                  `json:"violations"`
+	Notices          []Notice                     `json:"notices,omitempty"`
+	Profile          []ProfileEntry               `json:"profile,omitempty"`
+	Summary          Summary                      `json:"summary"`
 }
 
 // ProfileEntry is a single entry of profiling information, keyed by location.
@@ -199,6 +248,82 @@ func (r *Report) ViolationsFileCount() map[string]int {
 	return fc
 }
 
+// Merge merges reports from linter runs over disjoint sets of files, like the shards of a
+// sharded linter run, into a single report. Violations, aggregates and ignore directives are
+// combined, and notices deduplicated. The summary is recomputed from the merged data, except
+// for the number of files scanned, which is the sum of that from all reports.
+func Merge(reports ...Report) Report {
+	merged := Report{
+		Aggregates:       make(map[string][]Aggregate),
+		IgnoreDirectives: make(map[string][]IgnoreDirective),
+		Violations:       []Violation{},
+	}
+
+	for i := range reports {
+		merged.Violations = append(merged.Violations, reports[i].Violations...)
+		merged.Summary.FilesScanned += reports[i].Summary.FilesScanned
+
+		for _, notice := range reports[i].Notices {
+			if !slices.Contains(merged.Notices, notice) {
+				merged.Notices = append(merged.Notices, notice)
+
+				if notice.Severity != "none" {
+					merged.Summary.RulesSkipped++
+				}
+			}
+		}
+
+		for k, aggregates := range reports[i].Aggregates {
+			// empty, but present, aggregates signal that the rule was invoked, and must be retained
+			if _, ok := merged.Aggregates[k]; !ok {
+				merged.Aggregates[k] = make([]Aggregate, 0, len(aggregates))
+			}
+
+			merged.Aggregates[k] = append(merged.Aggregates[k], aggregates...)
+		}
+
+		maps.Copy(merged.IgnoreDirectives, reports[i].IgnoreDirectives)
+	}
+
+	SortViolations(merged.Violations)
+
+	merged.Summary.FilesFailed = len(merged.ViolationsFileCount())
+	merged.Summary.NumViolations = len(merged.Violations)
+
+	return merged
+}
+
+// Fingerprint computes a stable identifier for a violation, derived from the rule that reported
+// it, the file it was found in, the name of the rule enclosing the violation (if any), and the
+// offending text, normalized to not be affected by changes in whitespace. The occurrence is the
+// 0-based index of this violation among violations otherwise identical, and is used to tell
+// those apart. Notably, neither row nor column is included, as these change whenever code
+// above the violation is modified.
+func Fingerprint(v Violation, enclosingRule string, occurrence int) string {
+	text := ""
+	if v.Location.Text != nil {
+		text = strings.Join(strings.Fields(*v.Location.Text), " ")
+	}
+
+	h := sha256.New()
+
+	for _, part := range []string{v.Category, v.Title, v.Location.File, enclosingRule, text, strconv.Itoa(occurrence)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// SortViolations sorts violations in place by file, making the order of violations independent
+// of the order in which files were linted. The sort is stable, so the order in which violations
+// were reported for any given file is retained.
+func SortViolations(violations []Violation) {
+	slices.SortStableFunc(violations, func(a, b Violation) int {
+		return strings.Compare(a.Location.File, b.Location.File)
+	})
+}
+
 // String shorthand form for a Location.
 func (l Location) String() string {
 	if l.Row == 0 && l.Column == 0 {
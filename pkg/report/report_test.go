@@ -0,0 +1,159 @@
+package report
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	base := violation("a", "p.rego", "x := 1", "")
+	fingerprint := Fingerprint(base, "allow", 0)
+
+	moved := base
+	moved.Location.Row, moved.Location.Column = 10, 5
+
+	testCases := map[string]struct {
+		violation     Violation
+		enclosingRule string
+		occurrence    int
+		same          bool
+	}{
+		"identical":            {violation: base, enclosingRule: "allow", same: true},
+		"moved":                {violation: moved, enclosingRule: "allow", same: true},
+		"whitespace in text":   {violation: violation("a", "p.rego", " x  :=\t1 ", ""), enclosingRule: "allow", same: true},
+		"other rule":           {violation: violation("b", "p.rego", "x := 1", ""), enclosingRule: "allow"},
+		"other file":           {violation: violation("a", "q.rego", "x := 1", ""), enclosingRule: "allow"},
+		"other text":           {violation: violation("a", "p.rego", "x := 2", ""), enclosingRule: "allow"},
+		"other enclosing rule": {violation: base, enclosingRule: "deny"},
+		"other occurrence":     {violation: base, enclosingRule: "allow", occurrence: 1},
+		"no text":              {violation: Violation{Category: "style", Title: "a"}, enclosingRule: "allow"},
+		"parts separated":      {violation: violation("a", "p.regoallow", "x := 1", "")},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := Fingerprint(tc.violation, tc.enclosingRule, tc.occurrence)
+
+			if len(actual) != 32 {
+				t.Errorf("expected fingerprint of 32 characters, got %q", actual)
+			}
+
+			if same := actual == fingerprint; same != tc.same {
+				t.Errorf("expected same fingerprint to be %t, got %t", tc.same, same)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	notice := Notice{Title: "use-if", Category: "idiomatic", Level: "notice", Severity: "none"}
+	skipped := Notice{Title: "rule-named-if", Category: "bugs", Level: "notice", Severity: "warning"}
+
+	testCases := map[string]struct {
+		reports            []Report
+		expectedTitles     []string
+		expectedFiles      []string
+		expectedSummary    Summary
+		expectedNotices    []Notice
+		expectedAggregates map[string]int
+		expectedIgnores    []string
+	}{
+		"no reports": {
+			expectedAggregates: map[string]int{},
+		},
+		"shards": {
+			reports: []Report{
+				{
+					Violations: []Violation{violation("b", "q.rego", "", ""), violation("a", "q.rego", "", "")},
+					Summary:    Summary{FilesScanned: 2, FilesFailed: 1, NumViolations: 2},
+					Notices:    []Notice{notice, skipped},
+					Aggregates: map[string][]Aggregate{
+						"imports/unresolved-import":       {{"file": "q.rego"}},
+						"idiomatic/no-defined-entrypoint": {},
+					},
+					IgnoreDirectives: map[string][]IgnoreDirective{"q.rego": {{Rules: []string{"a"}}}},
+				},
+				{
+					Violations: []Violation{violation("c", "p.rego", "", "")},
+					Summary:    Summary{FilesScanned: 3, FilesFailed: 1, NumViolations: 1},
+					Notices:    []Notice{notice, skipped},
+					Aggregates: map[string][]Aggregate{
+						"imports/unresolved-import": {{"file": "p.rego"}},
+					},
+					IgnoreDirectives: map[string][]IgnoreDirective{"p.rego": {{Rules: []string{"c"}}}},
+				},
+			},
+			// sorted by file, retaining the order of violations within each file
+			expectedTitles:  []string{"c", "b", "a"},
+			expectedFiles:   []string{"p.rego", "q.rego", "q.rego"},
+			expectedSummary: Summary{FilesScanned: 5, FilesFailed: 2, NumViolations: 3, RulesSkipped: 1},
+			expectedNotices: []Notice{notice, skipped},
+			expectedAggregates: map[string]int{
+				"imports/unresolved-import":       2,
+				"idiomatic/no-defined-entrypoint": 0,
+			},
+			expectedIgnores: []string{"p.rego", "q.rego"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			merged := Merge(tc.reports...)
+
+			if merged.Violations == nil {
+				t.Errorf("expected violations to be empty rather than nil")
+			}
+
+			if titles := violationTitles(merged.Violations); !slices.Equal(titles, tc.expectedTitles) {
+				t.Errorf("expected violations %v, got %v", tc.expectedTitles, titles)
+			}
+
+			var files []string
+			for i := range merged.Violations {
+				files = append(files, merged.Violations[i].Location.File)
+			}
+
+			if !slices.Equal(files, tc.expectedFiles) {
+				t.Errorf("expected violations in files %v, got %v", tc.expectedFiles, files)
+			}
+
+			if merged.Summary != tc.expectedSummary {
+				t.Errorf("expected summary %+v, got %+v", tc.expectedSummary, merged.Summary)
+			}
+
+			if !slices.Equal(merged.Notices, tc.expectedNotices) {
+				t.Errorf("expected notices %v, got %v", tc.expectedNotices, merged.Notices)
+			}
+
+			if len(merged.Aggregates) != len(tc.expectedAggregates) {
+				t.Errorf("expected aggregates for %d rules, got %d", len(tc.expectedAggregates), len(merged.Aggregates))
+			}
+
+			for rule, n := range tc.expectedAggregates {
+				aggregates, ok := merged.Aggregates[rule]
+				if !ok || len(aggregates) != n {
+					t.Errorf("expected %d aggregates for %s, got %v", n, rule, aggregates)
+				}
+			}
+
+			var ignores []string
+			for file := range merged.IgnoreDirectives {
+				ignores = append(ignores, file)
+			}
+
+			slices.Sort(ignores)
+
+			if !slices.Equal(ignores, tc.expectedIgnores) {
+				t.Errorf("expected ignore directives for %v, got %v", tc.expectedIgnores, ignores)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package reporter
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/open-policy-agent/regal/internal/docs"
+	"github.com/open-policy-agent/regal/internal/embeds"
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// htmlSnippetContextLines is the number of lines shown before and after each violation in the HTML report.
+const htmlSnippetContextLines = 2
+
+// HTMLReporter reports violations as a single, self-contained HTML document, suitable for
+// sharing with people who don't use Regal themselves, e.g. as a CI artifact.
+type HTMLReporter struct {
+	out io.Writer
+}
+
+type htmlReport struct {
+	Summary    report.Summary
+	Categories []htmlCount
+	Rules      []htmlCount
+	Files      []htmlFile
+	Violations []htmlViolation
+	Notices    []report.Notice
+	Profile    []report.ProfileEntry
+	Metrics    []htmlMetric
+}
+
+type htmlCount struct {
+	Name  string
+	URL   string
+	Count int
+}
+
+type htmlFile struct {
+	Name       string
+	Anchor     string
+	Violations []htmlViolation
+	Count      int
+}
+
+type htmlViolation struct {
+	report.Violation
+
+	Snippet []snippetLine
+}
+
+type htmlMetric struct {
+	Name  string
+	Value string
+}
+
+// NewHTMLReporter creates a new HTMLReporter.
+func NewHTMLReporter(out io.Writer) HTMLReporter {
+	return HTMLReporter{out: out}
+}
+
+// Publish prints a self-contained HTML report to the configured output.
+func (tr HTMLReporter) Publish(_ context.Context, r report.Report) error {
+	tpl, err := template.New("report.html.tpl").Funcs(template.FuncMap{
+		"pluralize": util.Pluralize,
+		"duration": func(ns int64) string {
+			return time.Duration(ns).String()
+		},
+	}).ParseFS(embeds.EmbedTemplatesFS, "templates/report/report.html.tpl")
+	if err != nil {
+		return fmt.Errorf("failed to parse HTML report template: %w", err)
+	}
+
+	if err = tpl.Execute(tr.out, newHTMLReport(r)); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+
+	return nil
+}
+
+func newHTMLReport(r report.Report) htmlReport {
+	hr := htmlReport{
+		Summary:    r.Summary,
+		Violations: make([]htmlViolation, 0, len(r.Violations)),
+		Profile:    r.Profile,
+	}
+
+	for _, notice := range r.Notices {
+		if notice.Severity != "none" {
+			hr.Notices = append(hr.Notices, notice)
+		}
+	}
+
+	sources := newSourceFiles()
+	categories, rules := map[string]int{}, map[string]int{}
+	ruleURLs, files := map[string]string{}, map[string][]htmlViolation{}
+
+	for _, violation := range r.Violations { //nolint:gocritic
+		hv := htmlViolation{Violation: violation, Snippet: sources.snippet(violation, htmlSnippetContextLines)}
+
+		hr.Violations = append(hr.Violations, hv)
+
+		rule := violation.Category + "/" + violation.Title
+
+		categories[violation.Category]++
+		rules[rule]++
+		ruleURLs[rule] = docs.ViolationURL(violation)
+		files[violation.Location.File] = append(files[violation.Location.File], hv)
+	}
+
+	hr.Categories = sortedCounts(categories, nil)
+	hr.Rules = sortedCounts(rules, ruleURLs)
+
+	for _, file := range sortedCounts(lengths(files), nil) {
+		hr.Files = append(hr.Files, htmlFile{
+			Name:       file.Name,
+			Anchor:     "file-" + strconv.Itoa(len(hr.Files)+1),
+			Count:      file.Count,
+			Violations: files[file.Name],
+		})
+	}
+
+	for name, value := range r.Metrics {
+		hr.Metrics = append(hr.Metrics, htmlMetric{Name: name, Value: fmt.Sprint(value)})
+	}
+
+	slices.SortFunc(hr.Metrics, func(a, b htmlMetric) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return hr
+}
+
+// sortedCounts returns counts sorted by count in descending order, and then by name.
+func sortedCounts(counts map[string]int, urls map[string]string) []htmlCount {
+	sorted := make([]htmlCount, 0, len(counts))
+	for name, count := range counts {
+		sorted = append(sorted, htmlCount{Name: name, URL: urls[name], Count: count})
+	}
+
+	slices.SortFunc(sorted, func(a, b htmlCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+
+	return sorted
+}
+
+func lengths[T any](m map[string][]T) map[string]int {
+	l := make(map[string]int, len(m))
+	for k, v := range m {
+		l[k] = len(v)
+	}
+
+	return l
+}
@@ -0,0 +1,227 @@
+package reporter
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/regal/internal/docs"
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// maxMarkdownLength is the maximum number of characters GitHub allows in the body of
+// an issue or pull request comment. Reports exceeding this size are truncated.
+const maxMarkdownLength = 65536
+
+// MarkdownReporter reports violations as GitHub flavored Markdown, suitable for pull request
+// comments and job summaries. Violations are summarized per category in collapsible sections,
+// followed by the details of the violations found in each file.
+type MarkdownReporter struct {
+	out       io.Writer
+	maxLength int
+}
+
+// NewMarkdownReporter creates a new MarkdownReporter.
+func NewMarkdownReporter(out io.Writer) MarkdownReporter {
+	return MarkdownReporter{out: out, maxLength: maxMarkdownLength}
+}
+
+// Publish prints a Markdown report to the configured output.
+func (tr MarkdownReporter) Publish(_ context.Context, r report.Report) error {
+	_, err := io.WriteString(tr.out, truncateMarkdown(tr.render(r), tr.maxLength))
+
+	return err
+}
+
+// truncateMarkdown cuts md at the last line that, followed by a truncation notice, fits within
+// limit. This is only needed when the summary preceding the details of each file doesn't fit,
+// as the details of files not fitting are left out of the report when it's rendered.
+func truncateMarkdown(md string, limit int) string {
+	if len(md) <= limit {
+		return md
+	}
+
+	notice := "\n> [!NOTE]\n> Report truncated. Run `regal lint` locally for the full report.\n"
+	closing := "\n</details>\n"
+
+	md = md[:max(limit-len(notice)-len(closing), 0)]
+	if i := strings.LastIndexByte(md, '\n'); i >= 0 {
+		md = md[:i+1]
+	}
+
+	// details of at most one category or file are cut, and need closing
+	if strings.Count(md, "<details>") > strings.Count(md, "</details>") {
+		md += closing
+	}
+
+	return md + notice
+}
+
+func (tr MarkdownReporter) render(r report.Report) string {
+	sb := &strings.Builder{}
+
+	sb.WriteString("### Regal Lint Report\n\n")
+	fmt.Fprintf(sb, "%d %s linted.", r.Summary.FilesScanned, util.Pluralize("file", r.Summary.FilesScanned))
+
+	if r.Summary.NumViolations == 0 {
+		sb.WriteString(" No violations found.\n")
+	} else {
+		fmt.Fprintf(sb, " **%d** %s found in %d %s.\n",
+			r.Summary.NumViolations, util.Pluralize("violation", r.Summary.NumViolations),
+			r.Summary.FilesFailed, util.Pluralize("file", r.Summary.FilesFailed),
+		)
+	}
+
+	if r.Summary.RulesSkipped > 0 {
+		fmt.Fprintf(sb, "\n%d %s skipped:\n\n", r.Summary.RulesSkipped, util.Pluralize("rule", r.Summary.RulesSkipped))
+
+		for _, notice := range r.Notices {
+			if notice.Severity != "none" {
+				fmt.Fprintf(sb, "- %s: %s\n", notice.Title, notice.Description)
+			}
+		}
+	}
+
+	if len(r.Violations) == 0 {
+		return sb.String()
+	}
+
+	writeMarkdownCategories(sb, r.Violations)
+
+	sb.WriteString("\n#### Files\n")
+
+	files := make([]string, 0)
+	violationsPerFile := map[string][]report.Violation{}
+
+	for _, violation := range r.Violations { //nolint:gocritic
+		if _, ok := violationsPerFile[violation.Location.File]; !ok {
+			files = append(files, violation.Location.File)
+		}
+
+		violationsPerFile[violation.Location.File] = append(violationsPerFile[violation.Location.File], violation)
+	}
+
+	slices.Sort(files)
+
+	// reserve some room for the truncation notice
+	limit := tr.maxLength - 200
+
+	for i, file := range files {
+		section := markdownFileSection(file, violationsPerFile[file])
+
+		if sb.Len()+len(section) > limit {
+			omitted := 0
+			for _, f := range files[i:] {
+				omitted += len(violationsPerFile[f])
+			}
+
+			fmt.Fprintf(sb, "\n> [!NOTE]\n> Report truncated. %d %s in %d %s not shown. "+
+				"Run `regal lint` locally for the full report.\n",
+				omitted, util.Pluralize("violation", omitted), len(files)-i, util.Pluralize("file", len(files)-i),
+			)
+
+			break
+		}
+
+		sb.WriteString(section)
+	}
+
+	return sb.String()
+}
+
+func writeMarkdownCategories(sb *strings.Builder, violations []report.Violation) {
+	type ruleCount struct {
+		title, level, url string
+		count             int
+	}
+
+	categories := make([]string, 0)
+	rulesPerCategory := map[string][]*ruleCount{}
+
+	for _, violation := range violations { //nolint:gocritic
+		rules, ok := rulesPerCategory[violation.Category]
+		if !ok {
+			categories = append(categories, violation.Category)
+		}
+
+		i := slices.IndexFunc(rules, func(rc *ruleCount) bool { return rc.title == violation.Title })
+		if i == -1 {
+			rules = append(rules, &ruleCount{
+				title: violation.Title,
+				level: violation.Level,
+				url:   docs.ViolationURL(violation),
+			})
+			i = len(rules) - 1
+		}
+
+		rules[i].count++
+		rulesPerCategory[violation.Category] = rules
+	}
+
+	slices.Sort(categories)
+
+	sb.WriteString("\n#### Violations by Category\n")
+
+	for _, category := range categories {
+		rules := rulesPerCategory[category]
+
+		slices.SortFunc(rules, func(a, b *ruleCount) int {
+			return cmp.Or(cmp.Compare(b.count, a.count), cmp.Compare(a.title, b.title))
+		})
+
+		total := 0
+		for _, rule := range rules {
+			total += rule.count
+		}
+
+		fmt.Fprintf(sb, "\n<details>\n<summary><b>%s</b> (%d %s)</summary>\n\n",
+			category, total, util.Pluralize("violation", total),
+		)
+		sb.WriteString("| Rule | Level | Count |\n")
+		sb.WriteString("| --- | --- | ---: |\n")
+
+		for _, rule := range rules {
+			title := "`" + rule.title + "`"
+			if rule.url != "" {
+				title = fmt.Sprintf("[%s](%s)", title, rule.url)
+			}
+
+			fmt.Fprintf(sb, "| %s | %s | %d |\n", title, rule.level, rule.count)
+		}
+
+		sb.WriteString("\n</details>\n")
+	}
+}
+
+func markdownFileSection(file string, violations []report.Violation) string {
+	sb := &strings.Builder{}
+
+	fmt.Fprintf(sb, "\n<details>\n<summary><code>%s</code> (%d %s)</summary>\n\n",
+		file, len(violations), util.Pluralize("violation", len(violations)),
+	)
+	sb.WriteString("| Line | Level | Rule | Description |\n")
+	sb.WriteString("| ---: | --- | --- | --- |\n")
+
+	for _, violation := range violations { //nolint:gocritic
+		rule := violation.Category + "/" + violation.Title
+		if url := docs.ViolationURL(violation); url != "" {
+			rule = fmt.Sprintf("[%s](%s)", rule, url)
+		}
+
+		fmt.Fprintf(sb, "| %d | %s | %s | %s |\n",
+			violation.Location.Row, violation.Level, rule, escapeMarkdownTableCell(violation.Description),
+		)
+	}
+
+	sb.WriteString("\n</details>\n")
+
+	return sb.String()
+}
+
+func escapeMarkdownTableCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", "\\|"), "\n", " ")
+}
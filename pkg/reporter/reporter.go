@@ -3,11 +3,14 @@ package reporter
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"github.com/jstemmer/go-junit-report/v2/junit"
@@ -16,13 +19,15 @@ import (
 
 	outil "github.com/open-policy-agent/opa/v1/util"
 
+	"github.com/open-policy-agent/regal/internal/docs"
 	"github.com/open-policy-agent/regal/internal/mode"
 	"github.com/open-policy-agent/regal/internal/novelty"
+	"github.com/open-policy-agent/regal/internal/util"
 	"github.com/open-policy-agent/regal/pkg/fixer"
 	"github.com/open-policy-agent/regal/pkg/fixer/fixes"
 	"github.com/open-policy-agent/regal/pkg/report"
 	"github.com/open-policy-agent/regal/pkg/roast/encoding"
-	"github.com/open-policy-agent/regal/pkg/roast/util"
+	rutil "github.com/open-policy-agent/regal/pkg/roast/util"
 )
 
 // Reporter releases linter reports in a format decided by the implementation.
@@ -31,9 +36,22 @@ type Reporter interface {
 	Publish(context.Context, report.Report) error
 }
 
-// PrettyReporter is a Reporter for representing reports as tables.
+// StreamingReporter releases linter results incrementally, as they become available. Violations
+// are published per file as soon as that file has been linted, followed by the final report once
+// all files, and any aggregate rules, have been processed.
+type StreamingReporter interface {
+	Reporter
+	// PublishFile releases the violations found in a single file
+	PublishFile(ctx context.Context, file string, violations []report.Violation) error
+	// Finish releases the violations reported by aggregate rules, and the summary of the final report
+	Finish(ctx context.Context, r report.Report) error
+}
+
+// PrettyReporter is a Reporter for representing reports in a human-readable form, showing the
+// source code around each violation.
 type PrettyReporter struct {
-	out io.Writer
+	out          io.Writer
+	contextLines int
 }
 
 // CompactReporter reports violations in a compact table.
@@ -46,6 +64,12 @@ type JSONReporter struct {
 	out io.Writer
 }
 
+// JSONLinesReporter reports violations as JSON Lines (https://jsonlines.org/), with one
+// violation per line, followed by a summary line. This is a StreamingReporter.
+type JSONLinesReporter struct {
+	out io.Writer
+}
+
 // GitHubReporter reports violations in a format suitable for GitHub Actions.
 type GitHubReporter struct {
 	out io.Writer
@@ -67,9 +91,31 @@ type JUnitReporter struct {
 	out io.Writer
 }
 
+// CheckstyleReporter reports violations in the Checkstyle XML format, as consumed by tools like
+// the Jenkins Warnings NG plugin and reviewdog.
+type CheckstyleReporter struct {
+	out io.Writer
+}
+
+// GitLabReporter reports violations in the GitLab Code Quality JSON format
+// (https://docs.gitlab.com/ci/testing/code_quality/#code-quality-report-format).
+type GitLabReporter struct {
+	out io.Writer
+}
+
+// DefaultContextLines is the number of lines shown before and after each violation by the PrettyReporter.
+const DefaultContextLines = 2
+
 // NewPrettyReporter creates a new PrettyReporter.
 func NewPrettyReporter(out io.Writer) PrettyReporter {
-	return PrettyReporter{out: out}
+	return PrettyReporter{out: out, contextLines: DefaultContextLines}
+}
+
+// WithContextLines sets the number of lines of source code shown before and after each violation.
+func (tr PrettyReporter) WithContextLines(contextLines int) PrettyReporter {
+	tr.contextLines = max(0, contextLines)
+
+	return tr
 }
 
 // NewCompactReporter creates a new CompactReporter.
@@ -82,6 +128,11 @@ func NewJSONReporter(out io.Writer) JSONReporter {
 	return JSONReporter{out: out}
 }
 
+// NewJSONLinesReporter creates a new JSONLinesReporter.
+func NewJSONLinesReporter(out io.Writer) JSONLinesReporter {
+	return JSONLinesReporter{out: out}
+}
+
 // NewGitHubReporter creates a new GitHubReporter.
 func NewGitHubReporter(out io.Writer) GitHubReporter {
 	return GitHubReporter{out: out}
@@ -102,9 +153,19 @@ func NewJUnitReporter(out io.Writer) JUnitReporter {
 	return JUnitReporter{out: out}
 }
 
+// NewCheckstyleReporter creates a new CheckstyleReporter.
+func NewCheckstyleReporter(out io.Writer) CheckstyleReporter {
+	return CheckstyleReporter{out: out}
+}
+
+// NewGitLabReporter creates a new GitLabReporter.
+func NewGitLabReporter(out io.Writer) GitLabReporter {
+	return GitLabReporter{out: out}
+}
+
 // Publish prints a pretty report to the configured output.
 func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
-	table := buildPrettyViolationsTable(r.Violations)
+	violations := buildPrettyViolations(r.Violations, tr.contextLines)
 
 	numsWarning, numsError := 0, 0
 
@@ -117,23 +178,23 @@ func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
 		}
 	}
 
-	footer := fmt.Sprintf("%d %s linted.", r.Summary.FilesScanned, pluralize("file", r.Summary.FilesScanned))
+	footer := fmt.Sprintf("%d %s linted.", r.Summary.FilesScanned, util.Pluralize("file", r.Summary.FilesScanned))
 
 	if r.Summary.NumViolations == 0 {
 		footer += " No violations found."
 	} else {
-		footer += fmt.Sprintf(" %d %s ", r.Summary.NumViolations, pluralize("violation", r.Summary.NumViolations))
+		footer += fmt.Sprintf(" %d %s ", r.Summary.NumViolations, util.Pluralize("violation", r.Summary.NumViolations))
 
 		if numsWarning > 0 {
 			footer += fmt.Sprintf("(%d %s, %d %s) found",
-				numsError, pluralize("error", numsError), numsWarning, pluralize("warning", numsWarning),
+				numsError, util.Pluralize("error", numsError), numsWarning, util.Pluralize("warning", numsWarning),
 			)
 		} else {
 			footer += "found"
 		}
 
 		if r.Summary.FilesScanned > 1 && r.Summary.FilesFailed > 0 {
-			footer += fmt.Sprintf(" in %d %s.", r.Summary.FilesFailed, pluralize("file", r.Summary.FilesFailed))
+			footer += fmt.Sprintf(" in %d %s.", r.Summary.FilesFailed, util.Pluralize("file", r.Summary.FilesFailed))
 		} else {
 			footer += "."
 		}
@@ -143,7 +204,7 @@ func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
 		footer += fmt.Sprintf(
 			" %d %s skipped:\n",
 			r.Summary.RulesSkipped,
-			pluralize("rule", r.Summary.RulesSkipped),
+			util.Pluralize("rule", r.Summary.RulesSkipped),
 		)
 
 		for _, notice := range r.Notices {
@@ -153,7 +214,7 @@ func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
 		}
 	}
 
-	_, err := fmt.Fprint(tr.out, table+footer+"\n")
+	_, err := fmt.Fprint(tr.out, violations+footer+"\n")
 	if err != nil {
 		return fmt.Errorf("failed to write report: %w", err)
 	}
@@ -164,7 +225,7 @@ func (tr PrettyReporter) Publish(_ context.Context, r report.Report) error {
 
 	f := fixer.NewFixer().RegisterFixes(fixes.NewDefaultFixes()...)
 
-	fixableViolations := util.NewSet[string]()
+	fixableViolations := rutil.NewSet[string]()
 
 	for i := range r.Violations {
 		if fix, ok := f.GetFixForName(r.Violations[i].Title); ok {
@@ -202,74 +263,164 @@ func (tr FestiveReporter) Publish(ctx context.Context, r report.Report) error {
 	return NewPrettyReporter(tr.out).Publish(ctx, r)
 }
 
-func buildPrettyViolationsTable(violations []report.Violation) string {
-	sb := &strings.Builder{}
-	table := tablewriter.NewWriter(sb)
-
-	table.SetNoWhiteSpace(true)
-	table.SetTablePadding("\t")
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("")
-	table.SetRowSeparator("")
-	table.SetHeaderLine(false)
-	table.SetBorder(false)
-	table.SetAutoWrapText(false)
-
-	numViolations := len(violations)
-
-	// Note: it's tempting to use table.SetColumnColor here, but for whatever reason, that requires using
-	// table.SetHeader as well, and we don't want a header for this format.
+// buildPrettyViolations renders violations in a style similar to that of the Rust compiler, with
+// violations of the same rule grouped together under a common header, and the source code of each
+// location shown with line numbers and carets underlining the offending code.
+func buildPrettyViolations(violations []report.Violation, contextLines int) string {
+	if len(violations) == 0 {
+		return ""
+	}
 
 	yellow := color.New(color.FgYellow).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
 	red := color.New(color.FgRed).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
 
-	// rangeValCopy, but Not performance sensitive
-	//nolint:gocritic
-	for i, violation := range violations {
-		description := red(violation.Description)
-		if violation.Level == "warning" {
-			description = yellow(violation.Description)
+	rules := make([]string, 0)
+	violationsPerRule := map[string][]report.Violation{}
+
+	for _, violation := range violations { //nolint:gocritic
+		rule := violation.Category + "/" + violation.Title
+		if _, ok := violationsPerRule[rule]; !ok {
+			rules = append(rules, rule)
 		}
 
-		table.Append([]string{yellow("Rule:"), violation.Title})
+		violationsPerRule[rule] = append(violationsPerRule[rule], violation)
+	}
+
+	sb := &strings.Builder{}
+	sources := newSourceFiles()
+
+	for i, rule := range rules {
+		group := violationsPerRule[rule]
 
-		// if there is no support for color, then we show the level in addition
-		// so that the level of the violation is still clear
-		if color.NoColor {
-			table.Append([]string{"Level:", violation.Level})
+		levelColor := red
+		if group[0].Level == "warning" {
+			levelColor = yellow
 		}
 
-		table.Append([]string{yellow("Description:"), description})
-		table.Append([]string{yellow("Category:"), violation.Category})
-		// End location ignored here as it's not too interesting in this format and line:column
-		// allows click-to-open.
-		table.Append([]string{yellow("Location:"), cyan(violation.Location.String())})
-
-		if violation.Location.Text != nil {
-			if len(*violation.Location.Text) > 117 {
-				table.Append([]string{yellow("Text:"), (*violation.Location.Text)[:117] + "..."})
-			} else {
-				table.Append([]string{yellow("Text:"), strings.TrimSpace(*violation.Location.Text)})
+		fmt.Fprintf(sb, "%s: %s\n", levelColor(group[0].Level+"["+rule+"]"), bold(group[0].Description))
+
+		snippets := make([][]snippetLine, len(group))
+		width := 1
+
+		for j := range group {
+			snippets[j] = sources.snippet(group[j], contextLines)
+			width = max(width, len(strconv.Itoa(lastLineNumber(snippets[j]))))
+		}
+
+		gutter := strings.Repeat(" ", width)
+
+		for j, violation := range group { //nolint:gocritic
+			fmt.Fprintf(sb, "%s%s %s\n", gutter, cyan("-->"), violation.Location.String())
+
+			if violation.Description != group[0].Description {
+				fmt.Fprintf(sb, "%s %s %s\n", gutter, cyan("="), violation.Description)
+			}
+
+			for _, related := range violation.RelatedLocations {
+				fmt.Fprintf(sb, "%s %s %s: %s\n", gutter, cyan("= note:"), related.Message, related.Location.String())
+			}
+
+			if len(snippets[j]) == 0 {
+				continue
 			}
+
+			fmt.Fprintf(sb, "%s %s\n", gutter, cyan("|"))
+
+			for _, line := range snippets[j] {
+				text, carets := renderSnippetLine(line, violation.Location)
+
+				fmt.Fprintf(sb, "%s%s\n", cyan(fmt.Sprintf("%*d |", width, line.Number)), prefixed(" ", text))
+
+				if carets != "" {
+					fmt.Fprintf(sb, "%s %s %s\n", gutter, cyan("|"), levelColor(carets))
+				}
+			}
+
+			fmt.Fprintf(sb, "%s %s\n", gutter, cyan("|"))
 		}
 
-		table.Append([]string{yellow("Documentation:"), cyan(getDocumentationURL(violation))})
+		if url := docs.ViolationURL(group[0]); url != "" {
+			fmt.Fprintf(sb, "%s %s %s\n", gutter, cyan("= see:"), cyan(url))
+		}
 
-		if i+1 < numViolations {
-			table.Append([]string{""})
+		if i+1 < len(rules) {
+			sb.WriteString("\n")
 		}
 	}
 
-	end := ""
-	if numViolations > 0 {
-		end = "\n"
+	return sb.String() + "\n"
+}
+
+// maxPrettyLineLength is the maximum number of characters shown of a single line of source code.
+const maxPrettyLineLength = 117
+
+// renderSnippetLine renders a line of source code for display, expanding tabs and truncating
+// lines too long to display. For lines within the location, carets underlining the part of the
+// line covered by the location are returned as well.
+func renderSnippetLine(line snippetLine, loc report.Location) (string, string) {
+	text := strings.TrimRight(strings.ReplaceAll(line.Text, "\t", "    "), " \r")
+	width := utf8.RuneCountInString(text)
+
+	truncated := false
+	if width > maxPrettyLineLength {
+		text, width, truncated = string([]rune(text)[:maxPrettyLineLength]), maxPrettyLineLength, true
+	}
+
+	if !line.Highlighted {
+		return text, ""
 	}
 
-	table.Render()
+	endRow, endCol := loc.Row, 0
+	if loc.End != nil {
+		endRow, endCol = loc.End.Row, loc.End.Column
+	}
+
+	// columns are byte offsets in the original line, so need to be adjusted for expanded tabs
+	// and characters encoded using more than one byte
+	displayColumn := func(col int) int {
+		col = max(1, min(col, len(line.Text)+1))
 
-	return sb.String() + end
+		return utf8.RuneCountInString(strings.ReplaceAll(line.Text[:col-1], "\t", "    ")) + 1
+	}
+
+	start := displayColumn(1 + len(line.Text) - len(strings.TrimLeft(line.Text, " \t")))
+	if line.Number == loc.Row {
+		start = displayColumn(loc.Column)
+	}
+
+	end := width + 1
+	if line.Number == endRow && endCol > 0 {
+		end = displayColumn(endCol)
+	}
+
+	// locations starting past what's shown are pointed out by a caret below the ellipsis
+	start = min(start, width+1)
+	end = min(end, width+1)
+
+	if truncated {
+		text += "..."
+	}
+
+	return text, strings.Repeat(" ", start-1) + strings.Repeat("^", max(1, end-start))
+}
+
+// prefixed returns s with prefix, unless s is empty, avoiding trailing whitespace in output.
+func prefixed(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return prefix + s
+}
+
+func lastLineNumber(snippet []snippetLine) int {
+	if len(snippet) == 0 {
+		return 0
+	}
+
+	return snippet[len(snippet)-1].Number
 }
 
 // Publish prints a compact report to the configured output.
@@ -294,8 +445,8 @@ func (tr CompactReporter) Publish(_ context.Context, r report.Report) error {
 	}
 
 	summary := fmt.Sprintf("%d %s linted , %d %s found.",
-		r.Summary.FilesScanned, pluralize("file", r.Summary.FilesScanned),
-		r.Summary.NumViolations, pluralize("violation", r.Summary.NumViolations))
+		r.Summary.FilesScanned, util.Pluralize("file", r.Summary.FilesScanned),
+		r.Summary.NumViolations, util.Pluralize("violation", r.Summary.NumViolations))
 	// rendering the table
 	table.Render()
 
@@ -320,6 +471,65 @@ func (tr JSONReporter) Publish(_ context.Context, r report.Report) error {
 	return err
 }
 
+// jsonLine is a single line of output from the JSONLinesReporter.
+type jsonLine struct {
+	Type      string            `json:"type"`
+	Violation *report.Violation `json:"violation,omitempty"`
+	Summary   *report.Summary   `json:"summary,omitempty"`
+	Notices   []report.Notice   `json:"notices,omitempty"`
+}
+
+// Publish prints all violations of the report as JSON Lines, followed by the summary.
+func (tr JSONLinesReporter) Publish(ctx context.Context, r report.Report) error {
+	for i := range r.Violations {
+		if err := tr.writeLine(jsonLine{Type: "violation", Violation: &r.Violations[i]}); err != nil {
+			return err
+		}
+	}
+
+	return tr.writeSummary(ctx, r)
+}
+
+// PublishFile prints the violations found in a single file as JSON Lines.
+func (tr JSONLinesReporter) PublishFile(_ context.Context, _ string, violations []report.Violation) error {
+	for i := range violations {
+		if err := tr.writeLine(jsonLine{Type: "violation", Violation: &violations[i]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Finish prints the violations reported by aggregate rules, followed by the summary. Violations
+// from non-aggregate rules are assumed to have been published already by PublishFile.
+func (tr JSONLinesReporter) Finish(ctx context.Context, r report.Report) error {
+	for i := range r.Violations {
+		if r.Violations[i].IsAggregate {
+			if err := tr.writeLine(jsonLine{Type: "violation", Violation: &r.Violations[i]}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tr.writeSummary(ctx, r)
+}
+
+func (tr JSONLinesReporter) writeSummary(_ context.Context, r report.Report) error {
+	return tr.writeLine(jsonLine{Type: "summary", Summary: &r.Summary, Notices: r.Notices})
+}
+
+func (tr JSONLinesReporter) writeLine(line jsonLine) error {
+	bs, err := encoding.JSON().Marshal(line)
+	if err != nil {
+		return fmt.Errorf("json marshalling of %s failed: %w", line.Type, err)
+	}
+
+	_, err = fmt.Fprintln(tr.out, outil.ByteSliceToString(bs))
+
+	return err
+}
+
 // Publish first prints the pretty formatted report to console for easy access in the logs. It then goes on
 // to print the GitHub Actions annotations for each violation. Finally, it prints a summary of the report suitable
 // for the GitHub Actions UI.
@@ -339,7 +549,7 @@ func (tr GitHubReporter) Publish(ctx context.Context, r report.Report) error {
 			violation.Location.File,
 			violation.Location.Row,
 			violation.Location.Column,
-			fmt.Sprintf("%s. To learn more, see: %s", violation.Description, getDocumentationURL(violation)),
+			fmt.Sprintf("%s. To learn more, see: %s", violation.Description, docs.ViolationURL(violation)),
 		); err != nil {
 			return err
 		}
@@ -357,15 +567,16 @@ func (tr GitHubReporter) Publish(ctx context.Context, r report.Report) error {
 		}()
 
 		fmt.Fprintf(summaryFile, "### Regal Lint Report\n\n")
-		fmt.Fprintf(summaryFile, "%d %s linted.", r.Summary.FilesScanned, pluralize("file", r.Summary.FilesScanned))
+		fmt.Fprintf(summaryFile, "%d %s linted.", r.Summary.FilesScanned, util.Pluralize("file", r.Summary.FilesScanned))
 
 		if r.Summary.NumViolations == 0 {
 			fmt.Fprintf(summaryFile, " No violations found")
 		} else {
-			fmt.Fprintf(summaryFile, " %d %s found", r.Summary.NumViolations, pluralize("violation", r.Summary.NumViolations))
+			fmt.Fprintf(summaryFile, " %d %s found",
+				r.Summary.NumViolations, util.Pluralize("violation", r.Summary.NumViolations))
 
 			if r.Summary.FilesScanned > 1 && r.Summary.FilesFailed > 0 {
-				fmt.Fprintf(summaryFile, " in %d %s.", r.Summary.FilesFailed, pluralize("file", r.Summary.FilesFailed))
+				fmt.Fprintf(summaryFile, " in %d %s.", r.Summary.FilesFailed, util.Pluralize("file", r.Summary.FilesFailed))
 				fmt.Fprintf(summaryFile, " See Files tab in PR for locations and details.\n\n")
 
 				fmt.Fprintf(summaryFile, "#### Violations\n\n")
@@ -395,15 +606,30 @@ func (tr SarifReporter) Publish(_ context.Context, r report.Report) error {
 
 		run.AddRule(violation.Title).
 			WithDescription(violation.Description).
-			WithHelpURI(getDocumentationURL(violation)).
+			WithHelpURI(docs.ViolationURL(violation)).
 			WithProperties(pb.Properties)
 
 		run.AddDistinctArtifact(violation.Location.File)
 
-		run.CreateResultForRule(violation.Title).
+		result := run.CreateResultForRule(violation.Title).
 			WithLevel(violation.Level).
-			WithMessage(sarif.NewTextMessage(violation.Description)).
-			AddLocation(getLocation(violation))
+			WithMessage(sarif.NewTextMessage(violation.Description))
+
+		result.AddLocation(getLocation(violation.Location))
+
+		for i, related := range violation.RelatedLocations {
+			result.AddRelatedLocation(getLocation(related.Location).
+				WithId(i + 1).
+				WithMessage(sarif.NewTextMessage(related.Message)))
+		}
+
+		if violation.Fingerprint != "" {
+			result.WithPartialFingerPrints(map[string]any{sarifFingerprintKey: violation.Fingerprint})
+		}
+
+		if violation.Fix != nil {
+			result.AddFix(getFix(violation))
+		}
 	}
 
 	for _, notice := range r.Notices {
@@ -431,39 +657,50 @@ func (tr SarifReporter) Publish(_ context.Context, r report.Report) error {
 	return rep.PrettyWrite(tr.out)
 }
 
-func getLocation(violation report.Violation) *sarif.Location {
+// sarifFingerprintKey is the key of the violation fingerprint in partialFingerprints of SARIF results.
+// The version suffix is required by the SARIF spec, and must be bumped if the fingerprint algorithm changes.
+const sarifFingerprintKey = "regal/v1"
+
+func getLocation(location report.Location) *sarif.Location {
 	physicalLocation := sarif.NewPhysicalLocation().
-		WithArtifactLocation(sarif.NewSimpleArtifactLocation(violation.Location.File))
+		WithArtifactLocation(sarif.NewSimpleArtifactLocation(location.File))
 
 	region := sarif.NewRegion().
-		WithStartLine(violation.Location.Row).
-		WithStartColumn(violation.Location.Column)
+		WithStartLine(location.Row).
+		WithStartColumn(location.Column)
 
-	if violation.Location.End != nil {
-		region = region.WithEndLine(violation.Location.End.Row).WithEndColumn(violation.Location.End.Column)
+	if location.End != nil {
+		region = region.WithEndLine(location.End.Row).WithEndColumn(location.End.Column)
 	}
 
-	if violation.Location.Row > 0 && violation.Location.Column > 0 {
+	if location.Row > 0 && location.Column > 0 {
 		physicalLocation = physicalLocation.WithRegion(region)
 	}
 
 	return sarif.NewLocationWithPhysicalLocation(physicalLocation)
 }
 
-func getDocumentationURL(violation report.Violation) string {
-	for _, resource := range violation.RelatedResources {
-		if resource.Description == "documentation" {
-			return resource.Reference
-		}
+func getFix(violation report.Violation) *sarif.Fix {
+	change := sarif.NewArtifactChange(sarif.NewSimpleArtifactLocation(violation.Location.File))
+
+	for _, edit := range violation.Fix.Edits {
+		change.WithReplacement(sarif.NewReplacement(sarif.NewRegion().
+			WithStartLine(edit.Start.Row).
+			WithStartColumn(edit.Start.Column).
+			WithEndLine(edit.End.Row).
+			WithEndColumn(edit.End.Column),
+		).WithInsertedContent(sarif.NewArtifactContent().WithText(edit.NewText)))
 	}
 
-	return ""
+	return sarif.NewFix().
+		WithDescriptionText(violation.Fix.Description).
+		WithArtifactChanges([]*sarif.ArtifactChange{change})
 }
 
 func getUniqueViolationURLs(violations []report.Violation) map[string]string {
 	urls := make(map[string]string, len(violations))
 	for i := range violations {
-		urls[violations[i].Description] = getDocumentationURL(violations[i])
+		urls[violations[i].Description] = docs.ViolationURL(violations[i])
 	}
 
 	return urls
@@ -501,7 +738,7 @@ func (tr JUnitReporter) Publish(_ context.Context, r report.Report) error {
 				Name:      fmt.Sprintf("%s/%s: %s", violation.Category, violation.Title, violation.Description),
 				Classname: violation.Location.String(),
 				Failure: &junit.Result{
-					Message: fmt.Sprintf("%s. To learn more, see: %s", violation.Description, getDocumentationURL(violation)),
+					Message: fmt.Sprintf("%s. To learn more, see: %s", violation.Description, docs.ViolationURL(violation)),
 					Type:    violation.Level,
 					Data: fmt.Sprintf("Rule: %s\nDescription: %s\nCategory: %s\nLocation: %s\nText: %s\nDocumentation: %s",
 						violation.Title,
@@ -509,7 +746,7 @@ func (tr JUnitReporter) Publish(_ context.Context, r report.Report) error {
 						violation.Category,
 						violation.Location.String(),
 						text,
-						getDocumentationURL(violation)),
+						docs.ViolationURL(violation)),
 				},
 			})
 		}
@@ -520,10 +757,135 @@ func (tr JUnitReporter) Publish(_ context.Context, r report.Report) error {
 	return testSuites.WriteXML(tr.out)
 }
 
-func pluralize(singular string, count int) string {
-	if count == 1 {
-		return singular
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Publish prints a Checkstyle XML report to the configured output.
+func (tr CheckstyleReporter) Publish(_ context.Context, r report.Report) error {
+	files := make([]string, 0)
+	errorsPerFile := map[string][]checkstyleError{}
+
+	for _, violation := range r.Violations { //nolint:gocritic
+		if _, ok := errorsPerFile[violation.Location.File]; !ok {
+			files = append(files, violation.Location.File)
+		}
+
+		errorsPerFile[violation.Location.File] = append(errorsPerFile[violation.Location.File], checkstyleError{
+			Line:     violation.Location.Row,
+			Column:   violation.Location.Column,
+			Severity: checkstyleSeverity(violation.Level),
+			Message:  violation.Description,
+			Source:   fmt.Sprintf("regal.%s.%s", violation.Category, violation.Title),
+		})
+	}
+
+	slices.Sort(files)
+
+	cs := checkstyleReport{Version: "4.3", Files: make([]checkstyleFile, 0, len(files))}
+
+	for _, file := range files {
+		cs.Files = append(cs.Files, checkstyleFile{Name: file, Errors: errorsPerFile[file]})
+	}
+
+	bs, err := xml.MarshalIndent(cs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("xml marshalling of report failed: %w", err)
+	}
+
+	_, err = fmt.Fprintf(tr.out, "%s%s\n", xml.Header, bs)
+
+	return err
+}
+
+func checkstyleSeverity(level string) string {
+	switch level {
+	case "error", "warning":
+		return level
+	default:
+		return "info"
+	}
+}
+
+type gitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitLabLocation `json:"location"`
+}
+
+type gitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitLabLines `json:"lines"`
+}
+
+type gitLabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// Publish prints a GitLab Code Quality report to the configured output.
+func (tr GitLabReporter) Publish(_ context.Context, r report.Report) error {
+	issues := make([]gitLabIssue, 0, len(r.Violations))
+
+	for i, violation := range r.Violations { //nolint:gocritic
+		fingerprint := violation.Fingerprint
+		if fingerprint == "" {
+			// GitLab requires a unique fingerprint for each issue, so fall back to one
+			// based on the position in the report if the violation is missing one
+			fingerprint = report.Fingerprint(violation, "", i)
+		}
+
+		end := violation.Location.Row
+		if violation.Location.End != nil && violation.Location.End.Row > end {
+			end = violation.Location.End.Row
+		}
+
+		issues = append(issues, gitLabIssue{
+			Description: violation.Description,
+			CheckName:   violation.Category + "/" + violation.Title,
+			Fingerprint: fingerprint,
+			Severity:    gitLabSeverity(violation.Level),
+			Location: gitLabLocation{
+				Path:  violation.Location.File,
+				Lines: gitLabLines{Begin: violation.Location.Row, End: end},
+			},
+		})
 	}
 
-	return singular + "s"
+	bs, err := encoding.JSON().MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json marshalling of report failed: %w", err)
+	}
+
+	_, err = fmt.Fprintln(tr.out, outil.ByteSliceToString(bs))
+
+	return err
+}
+
+func gitLabSeverity(level string) string {
+	switch level {
+	case "error":
+		return "major"
+	case "warning":
+		return "minor"
+	default:
+		return "info"
+	}
 }
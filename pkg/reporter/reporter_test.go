@@ -2,7 +2,11 @@ package reporter
 
 import (
 	"bytes"
+	"encoding/xml"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -41,7 +45,8 @@ var rep = report.Report{
 					Reference:   "https://example.com/illegal",
 				},
 			},
-			Level: "error",
+			Level:       "error",
+			Fingerprint: "2a3c3a7e5f7b1c1e0b8e4d0c9f6a5b4d",
 		},
 		{
 			Title:       "questionable-decision",
@@ -136,6 +141,102 @@ func TestPrettyReporterPublishLongText(t *testing.T) {
 	}
 }
 
+func TestPrettyReporterPublishSnippets(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "p.rego")
+	policy := "package p\n\nallow if {\n\tinput.x == 1\n}\n\ndeny if {\n\tfalse\n}\n"
+	if err := os.WriteFile(file, []byte(policy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	violation := func(row, col, endRow, endCol int, description string) report.Violation {
+		return report.Violation{
+			Title:       "some-rule",
+			Description: description,
+			Category:    "testing",
+			Level:       "error",
+			Location: report.Location{
+				File: file, Row: row, Column: col, End: &report.Position{Row: endRow, Column: endCol},
+			},
+		}
+	}
+
+	r := report.Report{
+		Summary: report.Summary{FilesScanned: 1, NumViolations: 2, FilesFailed: 1},
+		Violations: []report.Violation{
+			violation(3, 1, 5, 2, "Some rule violated"),
+			violation(8, 2, 8, 7, "Some rule violated again"),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewPrettyReporter(&buf).WithContextLines(1).Publish(t.Context(), r); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := fmt.Sprintf(`error[testing/some-rule]: Some rule violated
+ --> %[1]s:3:1
+  |
+2 |
+3 | allow if {
+  | ^^^^^^^^^^
+4 |     input.x == 1
+  |     ^^^^^^^^^^^^
+5 | }
+  | ^
+  |
+ --> %[1]s:8:2
+  = Some rule violated again
+  |
+7 | deny if {
+8 |     false
+  |     ^^^^^
+9 | }
+  |
+
+1 file linted. 2 violations found.
+`, file)
+
+	if buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
+
+var relatedLocationsReport = report.Report{
+	Summary: report.Summary{FilesScanned: 1, NumViolations: 1, FilesFailed: 1},
+	Violations: []report.Violation{
+		{
+			Title:       "duplicate-rule",
+			Description: "Duplicate rule found at line 7",
+			Category:    "bugs",
+			Level:       "error",
+			Location: report.Location{
+				File: "policy.rego", Row: 3, Column: 1, End: &report.Position{Row: 5, Column: 2},
+			},
+			RelatedLocations: []report.RelatedLocation{{
+				Message: "Duplicate rule",
+				Location: report.Location{
+					File: "policy.rego", Row: 7, Column: 1, End: &report.Position{Row: 9, Column: 2},
+				},
+			}},
+		},
+	},
+}
+
+func TestPrettyReporterPublishRelatedLocations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewPrettyReporter(&buf).Publish(t.Context(), relatedLocationsReport); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := " = note: Duplicate rule: policy.rego:7:1\n"; !strings.Contains(buf.String(), expect) {
+		t.Errorf("expected output to contain %q, got %s", expect, buf.String())
+	}
+}
+
 func TestPrettyReporterPublishNoViolations(t *testing.T) {
 	t.Parallel()
 
@@ -210,6 +311,64 @@ func TestJSONReporterPublishNoViolations(t *testing.T) {
 	}
 }
 
+func TestJSONLinesReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewJSONLinesReporter(&buf).Publish(t.Context(), rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := MustReadFile(t, "testdata/jsonl/reporter.jsonl"); expect != buf.String() {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+}
+
+func TestJSONLinesReporterStreaming(t *testing.T) {
+	t.Parallel()
+
+	aggregate := report.Violation{
+		Title:       "aggregated",
+		Description: "Aggregate violation",
+		Category:    "imports",
+		Level:       "error",
+		Location:    report.Location{File: "c.rego", Row: 3, Column: 1},
+		IsAggregate: true,
+	}
+
+	final := rep
+	final.Violations = append(slices.Clone(rep.Violations), aggregate)
+
+	var buf bytes.Buffer
+
+	r := NewJSONLinesReporter(&buf)
+
+	for _, v := range rep.Violations { //nolint:gocritic
+		if err := r.PublishFile(t.Context(), v.Location.File, []report.Violation{v}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := r.Finish(t.Context(), final); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d: %s", len(lines), buf.String())
+	}
+
+	// only the aggregate violation should be published by Finish, as the others were
+	// already published per file
+	if !strings.Contains(lines[2], `"title":"aggregated"`) {
+		t.Errorf("expected aggregate violation on third line, got %s", lines[2])
+	}
+
+	if !strings.HasPrefix(lines[3], `{"type":"summary"`) {
+		t.Errorf("expected summary on last line, got %s", lines[3])
+	}
+}
+
 func TestGitHubReporterPublish(t *testing.T) {
 	// Can't use t.Parallel() here because t.Setenv() forbids that
 	t.Setenv("GITHUB_STEP_SUMMARY", "")
@@ -219,8 +378,10 @@ func TestGitHubReporterPublish(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if expectTable := "Rule:         \tbreaking-the-law"; !strings.Contains(buf.String(), expectTable) {
-		t.Errorf("expected table output %q, got %q", expectTable, buf.String())
+	if expectPretty := "error[legal/breaking-the-law]: Rego must not break the law!"; !strings.Contains(
+		buf.String(), expectPretty,
+	) {
+		t.Errorf("expected pretty output %q, got %q", expectPretty, buf.String())
 	}
 
 	//nolint:lll
@@ -259,6 +420,54 @@ func TestSarifReporterPublish(t *testing.T) {
 	}
 }
 
+func TestSarifReporterPublishWithFix(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewSarifReporter(&buf).Publish(t.Context(), report.Report{
+		Violations: []report.Violation{
+			{
+				Title:       "use-assignment-operator",
+				Description: "Prefer := over = for assignment",
+				Category:    "style",
+				Location: report.Location{
+					File:   "policy.rego",
+					Row:    3,
+					Column: 7,
+				},
+				Level: "error",
+				Fix: &report.Fix{
+					Description: "Apply the use-assignment-operator fix",
+					Edits: []report.TextEdit{{
+						Start:   report.Position{Row: 3, Column: 1},
+						End:     report.Position{Row: 4, Column: 1},
+						NewText: "allow := true\n",
+					}},
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := MustReadFile(t, "testdata/sarif/reporter-fix.json"); buf.String() != expect {
+		t.Errorf("expected %s, got %s", expect, buf.String())
+	}
+}
+
+func TestSarifReporterPublishWithRelatedLocations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewSarifReporter(&buf).Publish(t.Context(), relatedLocationsReport); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := MustReadFile(t, "testdata/sarif/reporter-related-locations.json"); buf.String() != expect {
+		t.Errorf("expected %s, got %s", expect, buf.String())
+	}
+}
+
 // https://github.com/open-policy-agent/regal/issues/514
 func TestSarifReporterViolationWithoutRegion(t *testing.T) {
 	t.Parallel()
@@ -341,6 +550,227 @@ func TestJUnitReporterPublishViolationWithoutText(t *testing.T) {
 	}
 }
 
+func TestCheckstyleReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewCheckstyleReporter(&buf).Publish(t.Context(), rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := MustReadFile(t, "testdata/checkstyle/reporter.xml"); buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
+
+func TestCheckstyleReporterPublishNoViolations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewCheckstyleReporter(&buf).Publish(t.Context(), report.Report{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := xml.Header + "<checkstyle version=\"4.3\"></checkstyle>\n"; buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
+
+func TestGitLabReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewGitLabReporter(&buf).Publish(t.Context(), rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := MustReadFile(t, "testdata/gitlab/reporter.json"); buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
+
+func TestGitLabReporterPublishNoViolations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewGitLabReporter(&buf).Publish(t.Context(), report.Report{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != "[]\n" {
+		t.Errorf("expected empty array, got %s", buf.String())
+	}
+}
+
+func TestHTMLReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	r := rep
+	r.Profile = []report.ProfileEntry{
+		{Location: "regal/rules/legal/breaking_the_law.rego:10", TotalTimeNs: 1500000, NumEval: 3, NumRedo: 1},
+	}
+	r.Metrics = map[string]any{"timer_regal_lint_total_ns": 42000000, "counter_regal_files": 3}
+
+	var buf bytes.Buffer
+	if err := NewHTMLReporter(&buf).Publish(t.Context(), r); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := MustReadFile(t, "testdata/html/reporter.html"); buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
+
+func TestMarkdownReporterPublish(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	var buf bytes.Buffer
+	if err := NewMarkdownReporter(&buf).Publish(t.Context(), rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := MustReadFile(t, "testdata/markdown/reporter.md"); buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
+
+func TestMarkdownReporterPublishTruncated(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	var buf bytes.Buffer
+
+	tr := NewMarkdownReporter(&buf)
+	tr.maxLength = 1200
+
+	if err := tr.Publish(t.Context(), rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(buf.String(), "<code>b.rego</code>") {
+		t.Errorf("expected details of b.rego to be truncated, got \n%s", buf.String())
+	}
+
+	if !strings.Contains(buf.String(), "Report truncated. 1 violation in 1 file not shown.") {
+		t.Errorf("expected truncation notice, got \n%s", buf.String())
+	}
+
+	if buf.Len() > tr.maxLength {
+		t.Errorf("expected report to be at most %d characters, got %d", tr.maxLength, buf.Len())
+	}
+}
+
+func TestMarkdownReporterPublishTruncatesSummary(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	// too short for even the summary of violations by category
+	tr := NewMarkdownReporter(&buf)
+	tr.maxLength = 300
+
+	if err := tr.Publish(t.Context(), rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "### Regal Lint Report\n") {
+		t.Errorf("expected report to start with heading, got \n%s", buf.String())
+	}
+
+	if !strings.HasSuffix(buf.String(), "Report truncated. Run `regal lint` locally for the full report.\n") {
+		t.Errorf("expected truncation notice, got \n%s", buf.String())
+	}
+
+	if buf.Len() > tr.maxLength {
+		t.Errorf("expected report to be at most %d characters, got %d", tr.maxLength, buf.Len())
+	}
+}
+
+func TestMarkdownReporterPublishNotToStepSummary(t *testing.T) {
+	summaryFile := filepath.Join(t.TempDir(), "summary.md")
+	if err := os.WriteFile(summaryFile, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryFile)
+
+	var buf bytes.Buffer
+	if err := NewMarkdownReporter(&buf).Publish(t.Context(), rep); err != nil {
+		t.Fatal(err)
+	}
+
+	// writing to the job summary is left to those providing it as output file
+	if summary := MustReadFile(t, summaryFile); summary != "" {
+		t.Errorf("expected step summary to be left empty, got \n%s", summary)
+	}
+}
+
+func TestTemplateReporterPublish(t *testing.T) {
+	t.Parallel()
+
+	tpl, err := ParseTemplateFile("testdata/template/report.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewTemplateReporter(&buf, tpl).Publish(t.Context(), rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := MustReadFile(t, "testdata/template/report.txt"); buf.String() != expect {
+		t.Errorf("expected \n%s, got \n%s", expect, buf.String())
+	}
+}
+
+func TestParseTemplateFileInvalid(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "invalid.tmpl")
+	if err := os.WriteFile(path, []byte("{{ .Violations"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseTemplateFile(path); err == nil {
+		t.Fatal("expected error parsing invalid template")
+	}
+}
+
+func TestSourceFilesSnippet(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "p.rego")
+	if err := os.WriteFile(file, []byte("package p\n\nallow if {\n\tinput.x == 1\n}\n\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	violation := report.Violation{Location: report.Location{
+		File: file, Row: 3, Column: 1, End: &report.Position{Row: 4, Column: 14},
+	}}
+
+	expected := []snippetLine{
+		{Number: 2, Text: ""},
+		{Number: 3, Text: "allow if {", Highlighted: true},
+		{Number: 4, Text: "\tinput.x == 1", Highlighted: true},
+		{Number: 5, Text: "}"},
+	}
+
+	if got := newSourceFiles().snippet(violation, 1); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// missing files fall back to the text of the location
+	violation.Location.File = "missing.rego"
+	violation.Location.End = nil
+	violation.Location.Text = ptr("allow if {")
+
+	expected = []snippetLine{{Number: 3, Text: "allow if {", Highlighted: true}}
+
+	if got := newSourceFiles().snippet(violation, 1); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
 func MustReadFile(t *testing.T, path string) string {
 	t.Helper()
 
@@ -351,3 +781,62 @@ func MustReadFile(t *testing.T, path string) string {
 
 	return string(bs)
 }
+
+func TestRenderSnippetLine(t *testing.T) {
+	t.Parallel()
+
+	long := "x := \"" + strings.Repeat("é", 200) + "\""
+
+	testCases := map[string]struct {
+		line          snippetLine
+		loc           report.Location
+		expectedText  string
+		expectedCaret string
+	}{
+		"tabs expanded": {
+			line:          snippetLine{Number: 1, Text: "\tx := 1", Highlighted: true},
+			loc:           report.Location{Row: 1, Column: 2, End: &report.Position{Row: 1, Column: 3}},
+			expectedText:  "    x := 1",
+			expectedCaret: "    ^",
+		},
+		"multibyte characters before location": {
+			line:          snippetLine{Number: 1, Text: `x := "éé" + y`, Highlighted: true},
+			loc:           report.Location{Row: 1, Column: 15, End: &report.Position{Row: 1, Column: 16}},
+			expectedText:  `x := "éé" + y`,
+			expectedCaret: "            ^",
+		},
+		"truncated on character boundary": {
+			line:          snippetLine{Number: 1, Text: long, Highlighted: true},
+			loc:           report.Location{Row: 1, Column: 1, End: &report.Position{Row: 1, Column: 2}},
+			expectedText:  string([]rune(long)[:maxPrettyLineLength]) + "...",
+			expectedCaret: "^",
+		},
+		"location past truncation": {
+			line:          snippetLine{Number: 1, Text: long, Highlighted: true},
+			loc:           report.Location{Row: 1, Column: len(long), End: &report.Position{Row: 1, Column: len(long) + 1}},
+			expectedText:  string([]rune(long)[:maxPrettyLineLength]) + "...",
+			expectedCaret: strings.Repeat(" ", maxPrettyLineLength) + "^",
+		},
+		"not highlighted": {
+			line:         snippetLine{Number: 2, Text: "\tx := 1"},
+			loc:          report.Location{Row: 1, Column: 1},
+			expectedText: "    x := 1",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			text, caret := renderSnippetLine(tc.line, tc.loc)
+
+			if text != tc.expectedText {
+				t.Errorf("expected text %q, got %q", tc.expectedText, text)
+			}
+
+			if caret != tc.expectedCaret {
+				t.Errorf("expected caret %q, got %q", tc.expectedCaret, caret)
+			}
+		})
+	}
+}
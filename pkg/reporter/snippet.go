@@ -0,0 +1,91 @@
+package reporter
+
+import (
+	"os"
+	"strings"
+
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// snippetLine is a single line of source code shown around a violation.
+type snippetLine struct {
+	Text        string
+	Number      int
+	Highlighted bool
+}
+
+// sourceFiles reads source files referenced by violations, caching the result so that files
+// with many violations are only read once. Since reports may be published in a different
+// context than where they were produced (like from a merged or diffed report), files missing
+// on disk are not an error, and snippets will fall back to the text of the violation location.
+type sourceFiles struct {
+	lines map[string][]string
+}
+
+func newSourceFiles() *sourceFiles {
+	return &sourceFiles{lines: make(map[string][]string)}
+}
+
+func (sf *sourceFiles) read(file string) []string {
+	if lines, ok := sf.lines[file]; ok {
+		return lines
+	}
+
+	var lines []string
+
+	if bs, err := os.ReadFile(file); err == nil {
+		lines = strings.Split(strings.ReplaceAll(string(bs), "\r\n", "\n"), "\n")
+	}
+
+	sf.lines[file] = lines
+
+	return lines
+}
+
+// snippet returns the lines of the violation location, surrounded by up to contextLines lines
+// before and after it. Lines covered by the location are highlighted.
+func (sf *sourceFiles) snippet(violation report.Violation, contextLines int) []snippetLine {
+	loc := violation.Location
+	if loc.Row <= 0 {
+		return nil
+	}
+
+	endRow := loc.Row
+	if loc.End != nil && loc.End.Row > endRow {
+		endRow = loc.End.Row
+	}
+
+	lines := sf.read(loc.File)
+	if len(lines) < endRow {
+		if loc.Text == nil {
+			return nil
+		}
+
+		// no source available, so only show the text of the location itself
+		snippet := make([]snippetLine, 0, endRow-loc.Row+1)
+		for i, text := range strings.Split(*loc.Text, "\n") {
+			snippet = append(snippet, snippetLine{Number: loc.Row + i, Text: text, Highlighted: true})
+		}
+
+		return snippet
+	}
+
+	first := max(1, loc.Row-contextLines)
+	last := min(len(lines), endRow+contextLines)
+
+	// don't end a snippet on a trailing empty line
+	for last > endRow && strings.TrimSpace(lines[last-1]) == "" {
+		last--
+	}
+
+	snippet := make([]snippetLine, 0, last-first+1)
+	for row := first; row <= last; row++ {
+		snippet = append(snippet, snippetLine{
+			Number:      row,
+			Text:        lines[row-1],
+			Highlighted: row >= loc.Row && row <= endRow,
+		})
+	}
+
+	return snippet
+}
@@ -0,0 +1,104 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/fatih/color"
+
+	"github.com/open-policy-agent/regal/internal/docs"
+	"github.com/open-policy-agent/regal/internal/util"
+	"github.com/open-policy-agent/regal/pkg/report"
+)
+
+// TemplateReporter reports violations using a user-provided Go text/template, which is
+// rendered with the report.Report as data. See TemplateFuncs for the helper functions
+// available to templates in addition to the built-in ones.
+type TemplateReporter struct {
+	out      io.Writer
+	template *template.Template
+}
+
+// NewTemplateReporter creates a new TemplateReporter, rendering the provided template.
+// Use ParseTemplateFile to create a template with the helper functions available.
+func NewTemplateReporter(out io.Writer, tpl *template.Template) TemplateReporter {
+	return TemplateReporter{out: out, template: tpl}
+}
+
+// ParseTemplateFile parses the template at path, making the functions from TemplateFuncs
+// available to it.
+func ParseTemplateFile(path string) (*template.Template, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	tpl, err := template.New(filepath.Base(path)).Funcs(TemplateFuncs()).Parse(string(bs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template file %s: %w", path, err)
+	}
+
+	return tpl, nil
+}
+
+// TemplateFuncs returns the helper functions available to templates rendered by the TemplateReporter:
+//
+//   - relativePath: the path of a file relative to the current working directory
+//   - pluralize: the plural form of a word when count is not 1, e.g. {{ pluralize "file" 2 }}
+//   - levelColor: text colored according to a violation level, e.g. {{ levelColor .Level .Title }}
+//   - getDocumentationURL: the documentation URL of a violation, if any
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"relativePath":        relativePath,
+		"pluralize":           util.Pluralize,
+		"levelColor":          levelColor,
+		"getDocumentationURL": docs.ViolationURL,
+	}
+}
+
+// Publish renders the template with the report to the configured output.
+func (tr TemplateReporter) Publish(_ context.Context, r report.Report) error {
+	if r.Violations == nil {
+		r.Violations = []report.Violation{}
+	}
+
+	if err := tr.template.Execute(tr.out, r); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return nil
+}
+
+func relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
+func levelColor(level, text string) string {
+	switch level {
+	case "error":
+		return color.New(color.FgRed).Sprint(text)
+	case "warning":
+		return color.New(color.FgYellow).Sprint(text)
+	default:
+		return text
+	}
+}